@@ -1,12 +1,28 @@
 package main
 
 import (
+	"context"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	_ "wildberriesapi/docs"
+	"wildberriesapi/internal/advertising"
+	"wildberriesapi/internal/answers"
 	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/asyncreport"
+	"wildberriesapi/internal/audit"
+	"wildberriesapi/internal/catalog"
+	"wildberriesapi/internal/collector"
 	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/economics"
 	"wildberriesapi/internal/handlers"
 	"wildberriesapi/internal/logger"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/rules"
+	"wildberriesapi/internal/state"
+	"wildberriesapi/internal/stocksync"
 )
 
 // @title WB Analytics Collector Service API
@@ -24,45 +40,129 @@ func main() {
 	log.Info().Msg("🚀 Starting WB Analytics Collector Service")
 
 	// --- 2️⃣ Создаём общий контекст с отменой ---
-	//ctx, cancel := context.WithCancel(context.Background())
-	//defer cancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	tokens := make([]string, 0)
 	tokens = append(tokens, cfg.WBToken)
 	// --- 3️⃣ Инициализация клиентов ---
 	wbClient := api.NewWBClient(tokens, log)
 
-	handler := handlers.NewRouter(wbClient, log)
+	store, err := state.NewFileStore(cfg.StateDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("❌ Failed to init state store")
+	}
+
+	cat, err := catalog.New(store)
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to load product catalogue, starting empty")
+	}
+
+	auditLog, err := audit.NewFileLogger(cfg.AuditLogPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("❌ Failed to init audit log")
+	}
+
+	templates, err := answers.LoadTemplates(cfg.AnswerTemplatesFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("❌ Failed to load answer templates")
+	}
 
-	err := http.ListenAndServe(":"+cfg.ServerPort, handler)
+	advertRules, err := rules.LoadRules(cfg.AdvertRulesFile)
 	if err != nil {
-		return
+		log.Fatal().Err(err).Msg("❌ Failed to load advert rules")
+	}
+
+	if len(cfg.APIKeys) == 0 {
+		log.Warn().Msg("⚠️ API_KEYS is empty — write endpoints are disabled")
 	}
 
-	//pub, err := publisher.NewKafkaPublisher(cfg)
-	//if err != nil {
-	//	log.Fatal().Err(err).Msg("❌ Failed to create Kafka publisher")
-	//}
-	//defer pub.Close()
-	//
-	//// --- 4️⃣ Создаём коллектор ---
-	//coll := collector.NewCollector(cfg, wbClient, pub, log)
-	//
-	//// --- 5️⃣ Запуск планировщика ---
-	//go func() {
-	//	log.Info().Msgf("⏱️ Collector scheduler started (interval: %s)", cfg.PollInterval)
-	//	coll.Schedule(ctx)
-	//}()
-	//
-	//// --- 6️⃣ Graceful Shutdown ---
-	//sig := make(chan os.Signal, 1)
-	//signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-	//
-	//select {
-	//case <-sig:
-	//	log.Warn().Msg("🛑 Shutdown signal received, stopping service...")
-	//	cancel()
-	//	time.Sleep(2 * time.Second)
-	//}
+	stockSync := stocksync.NewService(wbClient, store, log)
+	unitEconomics := economics.NewService(wbClient, cat, cfg.CostFile, cfg.UnitEconomicsWarehouse, log)
+
+	handler := handlers.NewRouter(wbClient, log, cfg.APIKeys,
+		handlers.WithAudit(auditLog),
+		handlers.WithTemplates(templates),
+		handlers.WithCatalog(cat),
+		handlers.WithStockSync(stockSync),
+		handlers.WithEconomics(unitEconomics),
+	)
+
+	go func() {
+		if err := http.ListenAndServe(":"+cfg.ServerPort, handler); err != nil {
+			log.Error().Err(err).Msg("❌ HTTP server stopped")
+			cancel()
+		}
+	}()
+
+	// Остатки FBS из файла-фида синхронизируются независимо от Kafka
+	if cfg.FBSStockFeed != "" && cfg.FBSStockSyncInterval > 0 {
+		go stocksync.NewJob(stockSync, cfg.FBSStockFeed, cfg.FBSStockSyncTokenIdx, cfg.FBSStockSyncDryRun, cfg.FBSStockSyncInterval, log).Run(ctx)
+	}
+
+	// --- 4️⃣ Kafka + коллектор (без Kafka работает только HTTP API) ---
+	pub, err := publisher.NewKafkaPublisher(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to create Kafka publisher, collectors disabled")
+	} else {
+		defer pub.Close()
+
+		coll := collector.NewCollector(cfg, wbClient, pub, store, cat, log)
+
+		// --- 5️⃣ Запуск планировщика ---
+		go func() {
+			log.Info().Msgf("⏱️ Collector scheduler started (interval: %s)", cfg.PollInterval)
+			coll.Schedule(ctx)
+		}()
+
+		// Новые заказы FBS опрашиваются чаще основного цикла
+		go collector.NewFBSOrdersCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Кампании и статистика рекламы — отдельным циклом из-за лимита fullstats (1 запрос в минуту)
+		go collector.NewAdvertsCollector(cfg, wbClient, pub, log).Run(ctx)
+		go collector.NewAdvertKeywordsCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Коэффициенты приёмки меняются в течение дня — опрашиваются отдельным частым циклом
+		go collector.NewAcceptanceCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Асинхронные отчёты WB: задача → опрос статуса → скачивание; ID задач переживают перезапуск
+		go asyncreport.NewRunner(asyncreport.NewPaidStorage(wbClient), wbClient, pub, "wb.raw.paid_storage", store, cfg.PollInterval, log).Run(ctx)
+		go asyncreport.NewRunner(asyncreport.NewWarehouseRemains(wbClient), wbClient, pub, "wb.raw.warehouse_remains", store, cfg.PollInterval, log).Run(ctx)
+		go asyncreport.NewRunner(asyncreport.NewAcceptance(wbClient), wbClient, pub, "wb.raw.acceptance_report", store, cfg.PollInterval, log).Run(ctx)
+		// Удержания за габариты WB отдаёт синхронно — обычный цикл без задач
+		go collector.NewMeasurementPenaltiesCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Прогноз обнуления остатков — предупреждения при падении запаса ниже порога
+		go collector.NewStockForecastCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Процент выкупа и возвратов — заказы сводятся с продажами по srid раз в сутки
+		go collector.NewBuyoutCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// ABC/XYZ-анализ ассортимента — классы и их изменения
+		go collector.NewABCXYZCollector(cfg, wbClient, pub, store, log).Run(ctx)
+
+		// Юнит-экономика — раз в сутки, расчёт долгий из-за отчёта о хранении и лимитов статистики рекламы
+		go collector.NewUnitEconomicsCollector(cfg, unitEconomics, pub, store, log).Run(ctx)
+
+		// Правила управления рекламой — решения публикуются в Kafka, поэтому только вместе с ней
+		if len(advertRules) > 0 {
+			engine := rules.NewEngine(advertRules, wbClient, advertising.NewService(wbClient, log), pub, store, auditLog,
+				cfg.AdvertRulesInterval, cfg.AdvertRulesDryRun, log)
+			go engine.Run(ctx)
+		}
+	}
+
+	// --- 6️⃣ Graceful Shutdown ---
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-sig:
+		log.Warn().Msg("🛑 Shutdown signal received, stopping service...")
+		cancel()
+		time.Sleep(2 * time.Second)
+	case <-ctx.Done():
+	}
 
 	log.Info().Msg("✅ Service stopped gracefully")
 }
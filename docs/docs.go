@@ -15,20 +15,2696 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/acceptance_report/download": {
+            "get": {
+                "tags": [
+                    "Async Reports"
+                ],
+                "summary": "Скачать отчёт о платной приёмке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.AcceptanceReportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/acceptance_report/start": {
+            "get": {
+                "description": "Создаёт задание на генерацию отчёта о платной приёмке по каждому токену. Период — максимум 31 день. Требует X-API-Key.",
+                "tags": [
+                    "Async Reports"
+                ],
+                "summary": "Создать отчёт о платной приёмке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD)",
+                        "name": "dateTo",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/acceptance_report/status": {
+            "get": {
+                "tags": [
+                    "Async Reports"
+                ],
+                "summary": "Статус отчёта о платной приёмке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/bids": {
+            "post": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Изменить ставки CPM",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить запрос",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "{token_idx, bids: [{advert_id, nm_bids: [{nm, bid}]}]}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/budget": {
+            "get": {
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Бюджет кампании",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кампании",
+                        "name": "advert_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/budget/deposit": {
+            "post": {
+                "description": "source: 0 — счёт, 1 — баланс, 3 — бонусы. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Пополнить бюджет кампании",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только показать ожидаемый бюджет",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "{advert_id, sum, source, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/keywords/wasted": {
+            "get": {
+                "description": "Ранжирует фразы с кликами по расходу в дни, когда у всей кампании не было заказов (spendNoOrders), затем по общему расходу.\nWB не отдаёт заказы в разрезе фраз, поэтому оценка — на уровне «кампания × день»: рядом с каждой фразой\nвозвращаются заказы кампании в дни её расхода (campaignOrders). Без advert_id берутся все активные и приостановленные кампании.",
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Ключевые фразы с расходом в дни без заказов кампании",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Период в днях (по умолчанию 7, максимум 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID кампании",
+                        "name": "advert_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько фраз вернуть (по умолчанию 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/minus-phrases": {
+            "post": {
+                "description": "mode: add (по умолчанию), remove или replace. Возвращает списки до и после изменения. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Минус-фразы кампании",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только показать изменения",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "{advert_id, mode, phrases, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/adverts/status": {
+            "post": {
+                "description": "action: start, pause или stop. Перед отправкой проверяет текущий статус кампании. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adverts"
+                ],
+                "summary": "Запустить, приостановить или завершить кампанию",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить, без изменений в WB",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "{advert_id, action, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/answers/templates": {
+            "get": {
+                "description": "Возвращает загруженные шаблоны ответов на отзывы и вопросы. Требует X-API-Key.",
+                "tags": [
+                    "Feedbacks"
+                ],
+                "summary": "Шаблоны ответов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/assortment/abc-xyz": {
+            "get": {
+                "description": "ABC — по накопленной доле выручки (A — до 80%, B — до 95%, C — остальное),\nXYZ — по коэффициенту вариации недельных продаж (X — до 10%, Y — до 25%, Z — выше).\nВозвращает матрицу 3×3 (количество товаров и доля выручки в ячейке) и классы товаров.",
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "ABC/XYZ-анализ ассортимента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Окно продаж в днях (по умолчанию 90, минимум 14)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/analytics.ABCXYZMatrix"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/audit": {
+            "get": {
+                "description": "Возвращает записи журнала: кто, что и когда отправил в WB через сервис. Требует X-API-Key.",
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Журнал аудита",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Действие, например feedback.answer",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пользователь",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимум записей (последние)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buyouts": {
+            "get": {
+                "description": "Заказы за последние window дней сводятся с продажами (saleID на S) и возвратами (на R) по srid.\nНезавершённые заказы (без продажи и отмены) в процент выкупа не входят.",
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Процент выкупа и возвратов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Разрез: nm (по умолчанию), region или warehouse",
+                        "name": "by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Окно в днях (по умолчанию 30)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/analytics.BuyoutRate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/catalog": {
+            "get": {
+                "description": "Возвращает локальный каталог карточек (название, артикул продавца, бренд, предмет, габариты).\nС nmId или barcode — один товар.",
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Каталог товаров",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Артикул WB",
+                        "name": "nmId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Баркод",
+                        "name": "barcode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/economics/units": {
+            "get": {
+                "description": "Прибыль с одного выкупа по каждому nmId: цена со скидкой минус комиссия WB, логистика\n(с учётом невыкупов), платное хранение, реклама и себестоимость из файла COST_FILE.\nСтатьи без данных считаются как 0 и перечисляются в missing. Расчёт занимает несколько минут,\nсоздаёт отчёт о платном хранении и расходует лимит статистики рекламы, поэтому требует X-API-Key.\nГотовый ежедневный расчёт публикуется в топик wb.raw.unit_economics.",
+                "tags": [
+                    "Economics"
+                ],
+                "summary": "Юнит-экономика товаров",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD), по умолчанию — 7 дней назад",
+                        "name": "dateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD), по умолчанию — вчера",
+                        "name": "dateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Склад для тарифа логистики, по умолчанию — UNIT_ECONOMICS_WAREHOUSE",
+                        "name": "warehouse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Артикул WB (один товар)",
+                        "name": "nmId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/analytics.UnitEconomics"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/orders/new": {
+            "get": {
+                "description": "Возвращает новые сборочные задания по всем токенам",
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Новые сборочные задания FBS",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/orders/status": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Статусы сборочных заданий FBS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заданий через запятую",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/stickers": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Этикетки сборочных заданий FBS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заданий через запятую",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "svg, png, zplv, zplh (по умолчанию svg)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "58x40 или 40x30 (по умолчанию 58x40)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/stocks": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Остатки на складе продавца (FBS)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID склада продавца",
+                        "name": "warehouse_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SKU (баркоды) через запятую",
+                        "name": "skus",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/stocks/sync": {
+            "post": {
+                "description": "Принимает фид остатков (JSON-массив [{warehouse_id, sku, amount}] или CSV с заголовком warehouse_id,sku,amount),\nсравнивает с остатками в WB и отправляет только расхождения. С dryRun=true только возвращает расхождения. Требует X-API-Key.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Синхронизировать остатки складов продавца с WB",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только показать расхождения",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/stocks/sync/last": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Отчёт последней синхронизации остатков FBS",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/supplies": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Поставки FBS",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Создать поставку FBS",
+                "parameters": [
+                    {
+                        "description": "{name, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/supplies/deliver": {
+            "post": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Передать поставку FBS в доставку",
+                "parameters": [
+                    {
+                        "description": "{supply_id, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "WB не подтвердил результат — проверьте состояние перед повтором",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/supplies/orders": {
+            "post": {
+                "description": "Возвращает ошибки по каждому заданию, которое не удалось добавить. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Добавить сборочные задания в поставку FBS",
+                "parameters": [
+                    {
+                        "description": "{supply_id, order_ids, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fbs/warehouses": {
+            "get": {
+                "tags": [
+                    "FBS"
+                ],
+                "summary": "Склады продавца (FBS)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feedbacks/answer": {
+            "post": {
+                "description": "Отправляет ответ на отзыв. Если text пуст — текст подбирается по шаблону (nmId + оценка). Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedbacks"
+                ],
+                "summary": "Ответить на отзыв",
+                "parameters": [
+                    {
+                        "description": "{id, text, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует ранее отправленный ответ на отзыв. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedbacks"
+                ],
+                "summary": "Изменить ответ на отзыв",
+                "parameters": [
+                    {
+                        "description": "{id, text, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feedbacks/unanswered": {
+            "get": {
+                "description": "Возвращает отзывы без ответа продавца по всем токенам",
+                "tags": [
+                    "Feedbacks"
+                ],
+                "summary": "Получить неотвеченные отзывы из WB API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Артикул WB",
+                        "name": "nmId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимум записей на токен",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feedbacks/viewed": {
+            "post": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedbacks"
+                ],
+                "summary": "Отметить отзыв просмотренным",
+                "parameters": [
+                    {
+                        "description": "{id, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/funnel/grouped": {
+            "get": {
+                "description": "Конверсии просмотр → корзина → заказ → выкуп по группам за период.\nС daily=true — по дням (период не больше 7 дней, история WB доступна только за последнюю неделю).",
+                "tags": [
+                    "Funnel"
+                ],
+                "summary": "Воронка продаж по предметам, брендам или ярлыкам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Группировка: subject, brand или tag",
+                        "name": "by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD), по умолчанию — 7 дней назад",
+                        "name": "dateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD), по умолчанию — вчера",
+                        "name": "dateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Разбивка по дням",
+                        "name": "daily",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/analytics.FunnelRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/api/incomes": {
             "get": {
-                "description": "Возвращает поставки за период",
+                "description": "Возвращает поставки за период",
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Получить поставки из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/measurement_penalties": {
+            "get": {
+                "description": "Отчёт WB синхронный: отдаётся сразу, без задачи и опроса статуса. Без token_idx — по всем токенам.",
+                "tags": [
+                    "Async Reports"
+                ],
+                "summary": "Удержания за занижение габаритов упаковки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "dateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.MeasurementPenalty"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders": {
+            "get": {
+                "description": "Возвращает список заказов за указанный период",
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Получить заказы из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD)",
+                        "name": "dateTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/paid_storage/download": {
+            "get": {
+                "description": "Метод возвращает отчёт о платном хранении по ID задания на генерацию.",
+                "tags": [
+                    "Paid Storage"
+                ],
+                "summary": "Получить отчёт из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/paid_storage/start": {
+            "get": {
+                "description": "Метод создаёт задание на генерацию отчёта о платном хранении.",
+                "tags": [
+                    "Paid Storage"
+                ],
+                "summary": "Создать отчёт из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD)",
+                        "name": "dateTo",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/paid_storage/status": {
+            "get": {
+                "description": "Возвращает статус задания на генерацию отчёта о платном хранении заказов за указанный период",
+                "tags": [
+                    "Paid Storage"
+                ],
+                "summary": "Проверить статус из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/prices/upload": {
+            "post": {
+                "description": "Создаёт задачи загрузки цен (пачками по 1000) и ждёт их обработки; ошибки возвращаются по nmID.\nС dryRun=true ничего не отправляет и возвращает diff с текущими ценами. Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Загрузить цены и скидки в WB",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только показать diff",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "{token_idx, items: [{nmID, price, discount}]}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/prices/upload/status": {
+            "get": {
+                "description": "Возвращает статус задачи загрузки цен и ошибки по nmID (для обработанных задач). Требует X-API-Key.",
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Статус загрузки цен",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задачи загрузки",
+                        "name": "uploadId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/questions/answer": {
+            "post": {
+                "description": "Отправляет ответ на вопрос покупателя. Если text пуст — текст подбирается по шаблону (nmId). Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Ответить на вопрос",
+                "parameters": [
+                    {
+                        "description": "{id, text, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Изменить ответ на вопрос",
+                "parameters": [
+                    {
+                        "description": "{id, text, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/questions/unanswered": {
+            "get": {
+                "description": "Возвращает вопросы покупателей без ответа продавца по всем токенам",
                 "tags": [
-                    "Incomes"
+                    "Questions"
                 ],
-                "summary": "Получить поставки из WB API",
+                "summary": "Получить неотвеченные вопросы из WB API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Артикул WB",
+                        "name": "nmId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимум записей на токен",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/questions/viewed": {
+            "post": {
+                "description": "Требует X-API-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Отметить вопрос просмотренным",
+                "parameters": [
+                    {
+                        "description": "{id, token_idx}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sales": {
+            "get": {
+                "description": "Возвращает продажи за период",
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Получить продажи из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocks": {
+            "get": {
+                "description": "Возвращает текущие остатки",
+                "tags": [
+                    "Stocks"
+                ],
+                "summary": "Получить остатки из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "dateFrom",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocks/forecast": {
+            "get": {
+                "description": "Запас в днях = остаток / средние заказы в день за окно; сортировка — сначала закончившиеся и самые срочные.\nСтатусы: out — товара нет, а заказы были; low — запаса меньше порога; ok — хватает.",
+                "tags": [
+                    "Stocks"
+                ],
+                "summary": "Прогноз обнуления остатков по складам WB",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Окно для скорости заказов в днях (по умолчанию 14)",
+                        "name": "velocity_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Порог запаса в днях (по умолчанию 14)",
+                        "name": "threshold_days",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "dateFrom",
-                        "in": "query",
-                        "required": true
+                        "description": "Только позиции со статусом: ok, low или out",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -37,8 +2713,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/analytics.StockCover"
                             }
                         }
                     },
@@ -63,25 +2738,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/orders": {
+        "/api/supplies/acceptance": {
             "get": {
-                "description": "Возвращает список заказов за указанный период",
+                "description": "Коэффициенты на ближайшие 14 дней: -1 — приёмка закрыта, 0 — бесплатно, N — множитель платной приёмки.",
                 "tags": [
-                    "Orders"
+                    "Supplies"
                 ],
-                "summary": "Получить заказы из WB API",
+                "summary": "Коэффициенты приёмки складов WB",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "dateFrom",
-                        "in": "query",
-                        "required": true
+                        "description": "ID складов через запятую",
+                        "name": "warehouse_ids",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Дата окончания (YYYY-MM-DD)",
-                        "name": "dateTo",
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
                         "in": "query"
                     }
                 ],
@@ -91,8 +2765,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/api.AcceptanceCoefficient"
                             }
                         }
                     },
@@ -117,26 +2790,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/paid_storage/download": {
+        "/api/supplies/cheapest": {
             "get": {
-                "description": "Метод возвращает отчёт о платном хранении по ID задания на генерацию.",
+                "description": "Склады с открытой приёмкой в периоде: для каждого — дата с минимальным коэффициентом.\nСортировка по коэффициенту приёмки, затем по стоимости логистики.",
                 "tags": [
-                    "Paid Storage"
+                    "Supplies"
                 ],
-                "summary": "Получить отчёт из WB API",
+                "summary": "Самые дешёвые склады для поставки",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
+                        "description": "Дата начала (YYYY-MM-DD), по умолчанию — сегодня",
                         "name": "dateFrom",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Дата окончания (YYYY-MM-DD)",
+                        "description": "Дата окончания (YYYY-MM-DD), по умолчанию — через 13 дней",
                         "name": "dateTo",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Тип упаковки: 2 — короба (по умолчанию), 5 — монопаллеты, 6 — суперсейф",
+                        "name": "box_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько складов вернуть (по умолчанию 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -145,8 +2835,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/analytics.WarehouseOption"
                             }
                         }
                     },
@@ -171,25 +2860,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/paid_storage/start": {
+        "/api/supplies/plan": {
             "get": {
-                "description": "Метод создаёт задание на генерацию отчёта о платном хранении.",
+                "description": "Сколько единиц каждого товара отгрузить на какой склад WB, чтобы запаса хватило на target_days дней.\nСпрос делится по федеральным округам покупателей и относится на самый дешёвый по логистике склад,\nкоторый реально отгружает в округ. Итог по товару не превышает нехватку всей сети с учётом остатков\nна других складах WB и возвратов в пути. Объёмы товаров берутся из каталога.",
                 "tags": [
-                    "Paid Storage"
+                    "Supplies"
                 ],
-                "summary": "Создать отчёт из WB API",
+                "summary": "План поставок FBW",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "dateFrom",
-                        "in": "query",
-                        "required": true
+                        "type": "number",
+                        "description": "Целевой запас в днях (по умолчанию 30)",
+                        "name": "target_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Окно для скорости заказов в днях (по умолчанию 14)",
+                        "name": "velocity_days",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Дата окончания (YYYY-MM-DD)",
-                        "name": "dateTo",
+                        "description": "Формат: json (по умолчанию), csv или xlsx",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -199,8 +2893,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/analytics.SupplyLine"
                             }
                         }
                     },
@@ -225,13 +2918,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/paid_storage/storage": {
+        "/api/supplies/warehouses": {
             "get": {
-                "description": "Возвращает статус задания на генерацию отчёта о платном хранении заказов за указанный период",
                 "tags": [
-                    "Paid Storage"
+                    "Supplies"
                 ],
-                "summary": "Проверить статус из WB API",
+                "summary": "Склады WB для поставок FBW",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер токена (с 1)",
+                        "name": "token_idx",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SupplyWarehouse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tariffs": {
+            "get": {
+                "description": "Метод возвращает данные о комиссии WB по родительским категориям товаров согласно модели продаж.",
+                "tags": [
+                    "Tariffs"
+                ],
+                "summary": "Получить Комиссия по категориям товаров из WB API",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -264,18 +2993,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sales": {
+        "/api/tariffs/box": {
             "get": {
-                "description": "Возвращает продажи за период",
+                "description": "Метод возвращает данные о комиссии WB по родительским категориям товаров согласно модели продаж.",
                 "tags": [
-                    "Sales"
+                    "Tariffs"
                 ],
-                "summary": "Получить продажи из WB API",
+                "summary": "Получить Комиссия по категориям товаров из WB API",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "dateFrom",
+                        "description": "Дата (YYYY-MM-DD)",
+                        "name": "date",
                         "in": "query",
                         "required": true
                     }
@@ -312,31 +3041,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/stocks": {
+        "/api/tariffs/calc": {
             "get": {
-                "description": "Возвращает текущие остатки",
+                "description": "Считает по тарифам WB на дату: логистика = первый литр + каждый следующий литр, хранение — в день.\nДля паллет хранение считается за паллету. Поля без тарифа склада возвращаются как null.",
                 "tags": [
-                    "Stocks"
+                    "Tariffs"
                 ],
-                "summary": "Получить остатки из WB API",
+                "summary": "Расчёт логистики и хранения единицы товара на складе",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "dateFrom",
+                        "description": "Название склада",
+                        "name": "warehouse",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Объём единицы товара, л",
+                        "name": "liters",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип тарифа: box (по умолчанию) или pallet",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата тарифа (YYYY-MM-DD), по умолчанию — сегодня",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "$ref": "#/definitions/analytics.LogisticsQuote"
                         }
                     },
                     "400": {
@@ -348,6 +3092,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -360,13 +3113,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tariffs": {
+        "/api/tariffs/pallet": {
             "get": {
                 "description": "Метод возвращает данные о комиссии WB по родительским категориям товаров согласно модели продаж.",
                 "tags": [
                     "Tariffs"
                 ],
                 "summary": "Получить Комиссия по категориям товаров из WB API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -399,18 +3161,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tariffs/box": {
+        "/api/warehouse_remains/download": {
             "get": {
-                "description": "Метод возвращает данные о комиссии WB по родительским категориям товаров согласно модели продаж.",
                 "tags": [
-                    "Tariffs"
+                    "Async Reports"
                 ],
-                "summary": "Получить Комиссия по категориям товаров из WB API",
+                "summary": "Скачать отчёт об остатках на складах WB",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата (YYYY-MM-DD)",
-                        "name": "date",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
                         "in": "query",
                         "required": true
                     }
@@ -421,8 +3189,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/api.WarehouseRemainsRow"
                             }
                         }
                     },
@@ -447,18 +3214,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tariffs/pallet": {
+        "/api/warehouse_remains/start": {
             "get": {
-                "description": "Метод возвращает данные о комиссии WB по родительским категориям товаров согласно модели продаж.",
+                "description": "Создаёт задание на генерацию отчёта об остатках (снимок на текущий момент) по каждому токену. Требует X-API-Key.",
                 "tags": [
-                    "Tariffs"
+                    "Async Reports"
                 ],
-                "summary": "Получить Комиссия по категориям товаров из WB API",
+                "summary": "Создать отчёт об остатках на складах WB",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/warehouse_remains/status": {
+            "get": {
+                "tags": [
+                    "Async Reports"
+                ],
+                "summary": "Статус отчёта об остатках на складах WB",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Дата (YYYY-MM-DD)",
-                        "name": "date",
+                        "description": "ID задания",
+                        "name": "taskId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер токена, создавшего задание (с 1)",
+                        "name": "token_idx",
                         "in": "query",
                         "required": true
                     }
@@ -467,11 +3270,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -495,6 +3295,681 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "definitions": {
+        "analytics.ABCXYZCell": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revenue_share": {
+                    "type": "number"
+                }
+            }
+        },
+        "analytics.ABCXYZItem": {
+            "type": "object",
+            "properties": {
+                "abc": {
+                    "type": "string"
+                },
+                "class": {
+                    "description": "например \"AX\"",
+                    "type": "string"
+                },
+                "cumulative_share": {
+                    "type": "number"
+                },
+                "cv": {
+                    "description": "CV — коэффициент вариации недельных продаж, %",
+                    "type": "number"
+                },
+                "nm_id": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "description": "выручка за окно за вычетом возвратов, ₽",
+                    "type": "number"
+                },
+                "revenue_share": {
+                    "description": "доля в выручке, %",
+                    "type": "number"
+                },
+                "supplier_article": {
+                    "type": "string"
+                },
+                "units": {
+                    "description": "продано за окно за вычетом возвратов",
+                    "type": "integer"
+                },
+                "xyz": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.ABCXYZMatrix": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/analytics.ABCXYZCell"
+                    }
+                },
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.ABCXYZItem"
+                    }
+                },
+                "window_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.BuyoutRate": {
+            "type": "object",
+            "properties": {
+                "buyout_pct": {
+                    "description": "выкупы / завершённые заказы",
+                    "type": "number"
+                },
+                "buyouts": {
+                    "description": "заказы с продажей (saleID на S)",
+                    "type": "integer"
+                },
+                "by": {
+                    "description": "nm, region, warehouse",
+                    "type": "string"
+                },
+                "cancelled": {
+                    "type": "integer"
+                },
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "nmId, регион или склад",
+                    "type": "string"
+                },
+                "net_buyout_pct": {
+                    "description": "(выкупы - возвраты) / завершённые заказы",
+                    "type": "number"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "return_pct": {
+                    "description": "возвраты / выкупы",
+                    "type": "number"
+                },
+                "returns": {
+                    "description": "выкупы, по которым прошёл возврат (saleID на R)",
+                    "type": "integer"
+                },
+                "window_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.FunnelRow": {
+            "type": "object",
+            "properties": {
+                "buyouts": {
+                    "type": "integer"
+                },
+                "buyouts_sum": {
+                    "type": "number"
+                },
+                "cart_to_order": {
+                    "description": "корзина → заказ, %",
+                    "type": "number"
+                },
+                "carts": {
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "пусто — итог за период",
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "order_to_buyout": {
+                    "description": "заказ → выкуп, %",
+                    "type": "number"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "orders_sum": {
+                    "type": "number"
+                },
+                "view_to_cart": {
+                    "description": "просмотр → корзина, %",
+                    "type": "number"
+                },
+                "view_to_order": {
+                    "description": "сквозная конверсия, %",
+                    "type": "number"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.LogisticsQuote": {
+            "type": "object",
+            "properties": {
+                "delivery_coef": {
+                    "description": "коэффициент логистики склада, %",
+                    "type": "number"
+                },
+                "delivery_cost": {
+                    "description": "логистика до покупателя, ₽",
+                    "type": "number"
+                },
+                "fbs_delivery_fee": {
+                    "description": "логистика FBS (только короба), ₽",
+                    "type": "number"
+                },
+                "liters": {
+                    "type": "number"
+                },
+                "storage_coef": {
+                    "description": "коэффициент хранения склада, %",
+                    "type": "number"
+                },
+                "storage_day": {
+                    "description": "хранение в день, ₽ (для паллет — за паллету)",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "box, pallet",
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.StockCover": {
+            "type": "object",
+            "properties": {
+                "days_of_cover": {
+                    "description": "DaysOfCover — на сколько дней хватит остатка; nil — заказов за окно не было",
+                    "type": "number"
+                },
+                "in_way_from_client": {
+                    "description": "возвраты в пути на склад",
+                    "type": "integer"
+                },
+                "in_way_to_client": {
+                    "description": "в пути к покупателю",
+                    "type": "integer"
+                },
+                "nm_id": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "description": "заказов за окно",
+                    "type": "integer"
+                },
+                "orders_per_day": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "description": "доступно к продаже",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock_out_date": {
+                    "description": "прогноз даты обнуления (YYYY-MM-DD)",
+                    "type": "string"
+                },
+                "supplier_article": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.SupplyLine": {
+            "type": "object",
+            "properties": {
+                "delivery_cost": {
+                    "description": "DeliveryCost — логистика единицы со склада; nil — нет тарифа склада или габаритов товара",
+                    "type": "number"
+                },
+                "in_way_from_client": {
+                    "type": "integer"
+                },
+                "logistics_total": {
+                    "description": "логистика всей поставки до покупателей",
+                    "type": "number"
+                },
+                "network_stock": {
+                    "description": "NetworkStock и InWayFromClient — остаток товара на всех складах WB и возвраты в пути на склады",
+                    "type": "integer"
+                },
+                "nm_id": {
+                    "type": "integer"
+                },
+                "orders_per_day": {
+                    "description": "спрос округов, отнесённый на склад",
+                    "type": "number"
+                },
+                "quantity": {
+                    "description": "к поставке",
+                    "type": "integer"
+                },
+                "regions": {
+                    "description": "округа, спрос которых закрывает склад",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stock": {
+                    "description": "текущий остаток на складе",
+                    "type": "integer"
+                },
+                "supplier_article": {
+                    "type": "string"
+                },
+                "target_qty": {
+                    "description": "запас на TargetDays дней",
+                    "type": "integer"
+                },
+                "volume_liters": {
+                    "type": "number"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.UnitEconomics": {
+            "type": "object",
+            "properties": {
+                "ad_spend": {
+                    "description": "реклама за весь период",
+                    "type": "number"
+                },
+                "advertising": {
+                    "type": "number"
+                },
+                "buyout_pct": {
+                    "type": "number"
+                },
+                "buyouts": {
+                    "type": "integer"
+                },
+                "commission": {
+                    "type": "number"
+                },
+                "commission_pct": {
+                    "type": "number"
+                },
+                "cost_price": {
+                    "type": "number"
+                },
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "discount_pct": {
+                    "type": "number"
+                },
+                "logistics": {
+                    "description": "доставка с учётом невыкупов и обратной логистики",
+                    "type": "number"
+                },
+                "margin_pct": {
+                    "description": "прибыль к цене продажи",
+                    "type": "number"
+                },
+                "missing": {
+                    "description": "Missing — статьи, по которым не нашлось данных и которые посчитаны как 0",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nm_id": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "roi_pct": {
+                    "description": "прибыль к себестоимости",
+                    "type": "number"
+                },
+                "sale_price": {
+                    "description": "цена продавца после скидки",
+                    "type": "number"
+                },
+                "storage": {
+                    "type": "number"
+                },
+                "subject_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "token_idx": {
+                    "type": "integer"
+                },
+                "vendor_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.WarehouseOption": {
+            "type": "object",
+            "properties": {
+                "available_dates": {
+                    "description": "дней с открытой приёмкой в периоде",
+                    "type": "integer"
+                },
+                "coefficient": {
+                    "description": "0 — бесплатная приёмка",
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "delivery_base": {
+                    "description": "логистика первого литра, ₽",
+                    "type": "number"
+                },
+                "delivery_liter": {
+                    "description": "логистика каждого следующего литра, ₽",
+                    "type": "number"
+                },
+                "free_dates": {
+                    "description": "дней с бесплатной приёмкой в периоде",
+                    "type": "integer"
+                },
+                "is_sorting_center": {
+                    "type": "boolean"
+                },
+                "storage_base": {
+                    "description": "хранение первого литра в день, ₽",
+                    "type": "number"
+                },
+                "storage_liter": {
+                    "description": "хранение следующего литра в день, ₽",
+                    "type": "number"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.AcceptanceCoefficient": {
+            "type": "object",
+            "properties": {
+                "allowUnload": {
+                    "type": "boolean"
+                },
+                "boxTypeID": {
+                    "type": "integer"
+                },
+                "boxTypeName": {
+                    "type": "string"
+                },
+                "coefficient": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deliveryAdditionalLiter": {
+                    "type": "string"
+                },
+                "deliveryBaseLiter": {
+                    "type": "string"
+                },
+                "deliveryCoef": {
+                    "type": "string"
+                },
+                "isSortingCenter": {
+                    "type": "boolean"
+                },
+                "storageAdditionalLiter": {
+                    "type": "string"
+                },
+                "storageBaseLiter": {
+                    "type": "string"
+                },
+                "storageCoef": {
+                    "type": "string"
+                },
+                "warehouseID": {
+                    "type": "integer"
+                },
+                "warehouseName": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.AcceptanceReportRow": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "количество товаров, шт.",
+                    "type": "integer"
+                },
+                "giCreateDate": {
+                    "description": "дата создания поставки",
+                    "type": "string"
+                },
+                "incomeId": {
+                    "description": "номер поставки",
+                    "type": "integer"
+                },
+                "nmID": {
+                    "description": "артикул WB",
+                    "type": "integer"
+                },
+                "shkCreateDate": {
+                    "description": "дата приёмки",
+                    "type": "string"
+                },
+                "subjectName": {
+                    "description": "предмет",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "суммарная стоимость приёмки, ₽",
+                    "type": "number"
+                }
+            }
+        },
+        "api.MeasurementPenalty": {
+            "type": "object",
+            "properties": {
+                "dimId": {
+                    "description": "ID замера — уникален, по нему удобно дедуплицировать",
+                    "type": "integer"
+                },
+                "dtBonus": {
+                    "description": "дата удержания",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "heightSup": {
+                    "type": "number"
+                },
+                "isValid": {
+                    "description": "замер подтверждён",
+                    "type": "boolean"
+                },
+                "isValidDt": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "lengthSup": {
+                    "type": "number"
+                },
+                "nmId": {
+                    "type": "integer"
+                },
+                "penaltyAmount": {
+                    "description": "сумма удержания, ₽",
+                    "type": "number"
+                },
+                "photoUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prcOver": {
+                    "description": "превышение объёма, %",
+                    "type": "number"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "token_idx": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "description": "объём по замеру WB, л",
+                    "type": "number"
+                },
+                "volumeSup": {
+                    "description": "объём и размеры, указанные продавцом",
+                    "type": "number"
+                },
+                "width": {
+                    "description": "размеры по замеру WB, см",
+                    "type": "number"
+                },
+                "widthSup": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.SupplyWarehouse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "acceptsQR": {
+                    "type": "boolean"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "isTransitActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "workTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.WarehouseRemain": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouseName": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.WarehouseRemainsRow": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "inWayFromClient": {
+                    "type": "integer"
+                },
+                "inWayToClient": {
+                    "type": "integer"
+                },
+                "nmId": {
+                    "type": "integer"
+                },
+                "quantityWarehousesFull": {
+                    "type": "integer"
+                },
+                "subjectName": {
+                    "type": "string"
+                },
+                "techSize": {
+                    "type": "string"
+                },
+                "vendorCode": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.WarehouseRemain"
+                    }
+                }
+            }
+        }
     }
 }`
 
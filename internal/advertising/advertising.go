@@ -0,0 +1,23 @@
+package advertising
+
+import (
+	"math"
+
+	"wildberriesapi/internal/api"
+
+	"github.com/rs/zerolog"
+)
+
+// Service — аналитика и управление рекламными кампаниями
+type Service struct {
+	api    *api.WBClient
+	logger zerolog.Logger
+}
+
+func NewService(client *api.WBClient, log zerolog.Logger) *Service {
+	return &Service{api: client, logger: log}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
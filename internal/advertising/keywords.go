@@ -0,0 +1,134 @@
+package advertising
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// WastedKeyword — ключевая фраза кампании с расходом за период. WB не отдаёт заказы в разрезе фраз,
+// поэтому «пустой» расход определяется на уровне кампании за день: SpendNoOrders — расход фразы в дни,
+// когда у всей кампании не было заказов; CampaignOrders — заказы кампании в дни, когда фраза тратила бюджет.
+type WastedKeyword struct {
+	AdvertID       int64   `json:"advertId"`
+	Keyword        string  `json:"keyword"`
+	Spend          float64 `json:"spend"`
+	SpendNoOrders  float64 `json:"spendNoOrders"`
+	Views          int64   `json:"views"`
+	Clicks         int64   `json:"clicks"`
+	CTR            float64 `json:"ctr"`
+	CPC            float64 `json:"cpc"`
+	Days           int     `json:"days"`
+	NoOrderDays    int     `json:"noOrderDays"`
+	CampaignOrders int64   `json:"campaignOrders"`
+	TokenIdx       int     `json:"token_idx"`
+}
+
+// WastedKeywords ранжирует ключевые фразы с кликами и расходом: сначала по расходу в дни без заказов
+// у кампании (SpendNoOrders), затем по общему расходу. Фразы кампаний, у которых заказы были, тоже
+// попадают в выдачу — рядом с ними видны заказы кампании, и эвристику можно оценить.
+// advertIDs пустой — берутся все активные и приостановленные кампании токена.
+func (s *Service) WastedKeywords(ctx context.Context, tokenIdx int, advertIDs []int64, from, to time.Time, limit int) ([]WastedKeyword, error) {
+	if tokenIdx == 0 {
+		tokenIdx = 1
+	}
+
+	if len(advertIDs) == 0 {
+		refs, err := s.api.GetAdvertIDs(ctx, tokenIdx, api.AdvertStatusActive, api.AdvertStatusPaused)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			advertIDs = append(advertIDs, ref.AdvertID)
+		}
+	}
+	if len(advertIDs) == 0 {
+		return []WastedKeyword{}, nil
+	}
+
+	// заказы кампании по дням
+	full, err := s.api.GetAdvertFullStats(ctx, tokenIdx, advertIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	orders := map[int64]map[string]int64{}
+	for _, st := range full {
+		byDay := map[string]int64{}
+		for _, d := range st.Days {
+			byDay[d.Date.Format("2006-01-02")] += d.Orders
+		}
+		orders[st.AdvertID] = byDay
+	}
+
+	type key struct {
+		advertID int64
+		keyword  string
+	}
+	acc := map[key]*WastedKeyword{}
+	for _, id := range advertIDs {
+		stats, err := s.api.GetAdvertKeywordStats(ctx, tokenIdx, id, from, to)
+		if err != nil {
+			s.logger.Warn().Err(err).Msgf("⚠️ keyword stats not available for advert %d", id)
+			continue
+		}
+		for _, st := range stats {
+			if st.Sum <= 0 {
+				continue
+			}
+			k := key{id, st.Keyword}
+			w, ok := acc[k]
+			if !ok {
+				w = &WastedKeyword{AdvertID: id, Keyword: st.Keyword, TokenIdx: tokenIdx}
+				acc[k] = w
+			}
+			dayOrders := orders[id][dateOnly(st.Date)]
+			w.Spend += st.Sum
+			w.Views += st.Views
+			w.Clicks += st.Clicks
+			w.Days++
+			w.CampaignOrders += dayOrders
+			if dayOrders == 0 {
+				w.SpendNoOrders += st.Sum
+				w.NoOrderDays++
+			}
+		}
+	}
+
+	out := make([]WastedKeyword, 0, len(acc))
+	for _, w := range acc {
+		// без кликов фраза не привела покупателей на карточку — заказов от неё и не ждём
+		if w.Clicks == 0 {
+			continue
+		}
+		if w.Views > 0 {
+			w.CTR = round2(float64(w.Clicks) / float64(w.Views) * 100)
+		}
+		w.CPC = round2(w.Spend / float64(w.Clicks))
+		w.Spend = round2(w.Spend)
+		w.SpendNoOrders = round2(w.SpendNoOrders)
+		out = append(out, *w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SpendNoOrders != out[j].SpendNoOrders {
+			return out[i].SpendNoOrders > out[j].SpendNoOrders
+		}
+		if out[i].Spend != out[j].Spend {
+			return out[i].Spend > out[j].Spend
+		}
+		return out[i].Keyword < out[j].Keyword
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// dateOnly обрезает дату WB ("2024-01-02T00:00:00Z" или "2024-01-02") до дня
+func dateOnly(s string) string {
+	if len(s) > 10 {
+		return s[:10]
+	}
+	return s
+}
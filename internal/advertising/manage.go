@@ -0,0 +1,284 @@
+package advertising
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"wildberriesapi/internal/api"
+)
+
+// Действия со статусом кампании
+const (
+	ActionStart = "start"
+	ActionPause = "pause"
+	ActionStop  = "stop"
+)
+
+// Режимы изменения минус-фраз
+const (
+	MinusAdd     = "add"
+	MinusRemove  = "remove"
+	MinusReplace = "replace"
+)
+
+var (
+	// ErrInvalidRequest — некорректные параметры запроса
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrNotAllowed — действие недоступно в текущем статусе кампании
+	ErrNotAllowed = errors.New("action not allowed")
+)
+
+// allowedFrom — из каких статусов WB допускает действие
+var allowedFrom = map[string][]int{
+	ActionStart: {api.AdvertStatusReady, api.AdvertStatusPaused},
+	ActionPause: {api.AdvertStatusActive},
+	ActionStop:  {api.AdvertStatusReady, api.AdvertStatusActive, api.AdvertStatusPaused},
+}
+
+// StatusChange — смена статуса кампании
+type StatusChange struct {
+	AdvertID int64  `json:"advertId"`
+	Action   string `json:"action"`
+	Status   int    `json:"status"` // статус до изменения
+	DryRun   bool   `json:"dryRun"`
+	Applied  bool   `json:"applied"`
+}
+
+// BudgetChange — пополнение бюджета кампании
+type BudgetChange struct {
+	AdvertID int64   `json:"advertId"`
+	Sum      int     `json:"sum"`
+	Source   int     `json:"source"`
+	Before   float64 `json:"before"`
+	After    float64 `json:"after"` // при dryRun — ожидаемый бюджет
+	DryRun   bool    `json:"dryRun"`
+	Applied  bool    `json:"applied"`
+}
+
+// BidsChange — изменение ставок
+type BidsChange struct {
+	Bids    []api.AdvertBid `json:"bids"`
+	DryRun  bool            `json:"dryRun"`
+	Applied bool            `json:"applied"`
+}
+
+// MinusChange — изменение минус-фраз кампании
+type MinusChange struct {
+	AdvertID int64    `json:"advertId"`
+	Mode     string   `json:"mode"`
+	Before   []string `json:"before"`
+	After    []string `json:"after"`
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	DryRun   bool     `json:"dryRun"`
+	Applied  bool     `json:"applied"`
+}
+
+func (s *Service) campaign(ctx context.Context, tokenIdx int, advertID int64) (*api.AdvertCampaign, error) {
+	campaigns, err := s.api.GetAdvertDetails(ctx, tokenIdx, []int64{advertID})
+	if err != nil {
+		return nil, err
+	}
+	if len(campaigns) == 0 {
+		return nil, fmt.Errorf("advert %d not found", advertID)
+	}
+	return &campaigns[0], nil
+}
+
+// ChangeStatus запускает, приостанавливает или завершает кампанию, предварительно проверив её текущий статус
+func (s *Service) ChangeStatus(ctx context.Context, tokenIdx int, advertID int64, action string, dryRun bool) (*StatusChange, error) {
+	allowed, ok := allowedFrom[action]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidRequest, action)
+	}
+
+	camp, err := s.campaign(ctx, tokenIdx, advertID)
+	if err != nil {
+		return nil, err
+	}
+	res := &StatusChange{AdvertID: advertID, Action: action, Status: camp.Status, DryRun: dryRun}
+
+	permitted := false
+	for _, st := range allowed {
+		if camp.Status == st {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return res, fmt.Errorf("%w: advert %d: cannot %s from status %d", ErrNotAllowed, advertID, action, camp.Status)
+	}
+	if dryRun {
+		return res, nil
+	}
+
+	switch action {
+	case ActionStart:
+		err = s.api.StartAdvert(ctx, tokenIdx, advertID)
+	case ActionPause:
+		err = s.api.PauseAdvert(ctx, tokenIdx, advertID)
+	case ActionStop:
+		err = s.api.StopAdvert(ctx, tokenIdx, advertID)
+	}
+	res.Applied = err == nil
+	return res, err
+}
+
+// Deposit пополняет бюджет кампании
+func (s *Service) Deposit(ctx context.Context, tokenIdx int, advertID int64, sum, source int, dryRun bool) (*BudgetChange, error) {
+	if sum <= 0 {
+		return nil, fmt.Errorf("%w: sum must be positive", ErrInvalidRequest)
+	}
+	if source != api.AdvertDepositAccount && source != api.AdvertDepositBalance && source != api.AdvertDepositBonus {
+		return nil, fmt.Errorf("%w: unknown deposit source %d", ErrInvalidRequest, source)
+	}
+
+	budget, err := s.api.GetAdvertBudget(ctx, tokenIdx, advertID)
+	if err != nil {
+		return nil, err
+	}
+	res := &BudgetChange{
+		AdvertID: advertID,
+		Sum:      sum,
+		Source:   source,
+		Before:   budget.Total,
+		After:    budget.Total + float64(sum),
+		DryRun:   dryRun,
+	}
+	if dryRun {
+		return res, nil
+	}
+
+	total, err := s.api.DepositAdvertBudget(ctx, tokenIdx, advertID, sum, source)
+	if err != nil {
+		return res, err
+	}
+	res.After, res.Applied = total, true
+	return res, nil
+}
+
+// SetBids меняет ставки CPM по товарам
+func (s *Service) SetBids(ctx context.Context, tokenIdx int, bids []api.AdvertBid, dryRun bool) (*BidsChange, error) {
+	if len(bids) == 0 {
+		return nil, fmt.Errorf("%w: no bids to set", ErrInvalidRequest)
+	}
+	for _, b := range bids {
+		if b.AdvertID <= 0 || len(b.NmBids) == 0 {
+			return nil, fmt.Errorf("%w: advert %d: advert_id and nm_bids are required", ErrInvalidRequest, b.AdvertID)
+		}
+		for _, nb := range b.NmBids {
+			if nb.Nm <= 0 || nb.Bid <= 0 {
+				return nil, fmt.Errorf("%w: advert %d: invalid bid %d for nm %d", ErrInvalidRequest, b.AdvertID, nb.Bid, nb.Nm)
+			}
+		}
+	}
+
+	res := &BidsChange{Bids: bids, DryRun: dryRun}
+	if dryRun {
+		return res, nil
+	}
+	if err := s.api.SetAdvertBids(ctx, tokenIdx, bids); err != nil {
+		return res, err
+	}
+	res.Applied = true
+	return res, nil
+}
+
+// SetMinusPhrases добавляет, удаляет или заменяет минус-фразы кампании
+func (s *Service) SetMinusPhrases(ctx context.Context, tokenIdx int, advertID int64, mode string, phrases []string, dryRun bool) (*MinusChange, error) {
+	if mode == "" {
+		mode = MinusAdd
+	}
+	if mode != MinusAdd && mode != MinusRemove && mode != MinusReplace {
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidRequest, mode)
+	}
+
+	camp, err := s.campaign(ctx, tokenIdx, advertID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.currentMinusPhrases(ctx, tokenIdx, camp)
+	if err != nil {
+		return nil, err
+	}
+
+	// фразы WB и запроса сравниваются в одном виде: иначе remove «платье» не снимет «Платье», а add создаст дубль
+	was := make(map[string]bool, len(before))
+	for _, p := range before {
+		if p = normalizePhrase(p); p != "" {
+			was[p] = true
+		}
+	}
+
+	next := map[string]bool{}
+	if mode != MinusReplace {
+		for p := range was {
+			next[p] = true
+		}
+	}
+	for _, p := range phrases {
+		if p = normalizePhrase(p); p == "" {
+			continue
+		}
+		next[p] = mode != MinusRemove
+	}
+
+	res := &MinusChange{AdvertID: advertID, Mode: mode, Before: before, After: make([]string, 0), Added: make([]string, 0), Removed: make([]string, 0), DryRun: dryRun}
+	for p, keep := range next {
+		if !keep {
+			continue
+		}
+		res.After = append(res.After, p)
+		if !was[p] {
+			res.Added = append(res.Added, p)
+		}
+	}
+	for p := range was {
+		if !next[p] {
+			res.Removed = append(res.Removed, p)
+		}
+	}
+	sort.Strings(res.After)
+	sort.Strings(res.Added)
+	sort.Strings(res.Removed)
+
+	if dryRun || (len(res.Added) == 0 && len(res.Removed) == 0) {
+		return res, nil
+	}
+	if err := s.api.SetAdvertExcluded(ctx, tokenIdx, advertID, camp.Type, res.After); err != nil {
+		return res, err
+	}
+	res.Applied = true
+	return res, nil
+}
+
+// normalizePhrase приводит минус-фразу к виду, в котором фразы сравниваются и отправляются в WB
+func normalizePhrase(p string) string {
+	return strings.ToLower(strings.TrimSpace(p))
+}
+
+func (s *Service) currentMinusPhrases(ctx context.Context, tokenIdx int, camp *api.AdvertCampaign) ([]string, error) {
+	out := make([]string, 0)
+	if camp.Type == api.AdvertTypeSearch {
+		words, err := s.api.GetAdvertSearchWords(ctx, tokenIdx, camp.AdvertID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, words.Words.Excluded...)
+	} else {
+		clusters, err := s.api.GetAdvertAutoClusters(ctx, tokenIdx, camp.AdvertID)
+		if err != nil {
+			return nil, err
+		}
+		for _, cl := range clusters {
+			if cl.Excluded {
+				out = append(out, cl.Cluster)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
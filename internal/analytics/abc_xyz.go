@@ -0,0 +1,199 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// Границы классов ABC (накопленная доля выручки) и XYZ (коэффициент вариации недельных продаж)
+const (
+	abcBoundA = 80.0
+	abcBoundB = 95.0
+	xyzBoundX = 10.0
+	xyzBoundY = 25.0
+)
+
+// ABCXYZItem — класс товара в ABC/XYZ-анализе
+type ABCXYZItem struct {
+	NmID            int64   `json:"nm_id"`
+	SupplierArticle string  `json:"supplier_article"`
+	Revenue         float64 `json:"revenue"`       // выручка за окно за вычетом возвратов, ₽
+	RevenueShare    float64 `json:"revenue_share"` // доля в выручке, %
+	CumulativeShare float64 `json:"cumulative_share"`
+	Units           int     `json:"units"` // продано за окно за вычетом возвратов
+	// CV — коэффициент вариации недельных продаж, %
+	CV    float64 `json:"cv"`
+	ABC   string  `json:"abc"`
+	XYZ   string  `json:"xyz"`
+	Class string  `json:"class"` // например "AX"
+}
+
+// ABCXYZCell — ячейка матрицы ABC/XYZ
+type ABCXYZCell struct {
+	Count        int     `json:"count"`
+	Revenue      float64 `json:"revenue"`
+	RevenueShare float64 `json:"revenue_share"`
+}
+
+// ABCXYZMatrix — результат анализа: матрица 3×3 (ключ — класс "AX" … "CZ") и товары
+type ABCXYZMatrix struct {
+	WindowDays int                   `json:"window_days"`
+	DateFrom   string                `json:"date_from"`
+	DateTo     string                `json:"date_to"`
+	Cells      map[string]ABCXYZCell `json:"cells"`
+	Items      []ABCXYZItem          `json:"items"`
+}
+
+// ABCXYZ классифицирует товары по продажам (saleID на S) и возвратам (на R) за последние windowDays дней до now.
+// ABC — по накопленной доле выручки (A — до 80%, B — до 95%, C — остальное);
+// XYZ — по коэффициенту вариации продаж по неделям (X — до 10%, Y — до 25%, Z — выше); недели отсчитываются
+// целыми от now назад, остаток окна короче недели в XYZ не входит. Товары без продаж за окно в анализ не попадают.
+func ABCXYZ(sales []api.WBRecord, windowDays int, now time.Time) ABCXYZMatrix {
+	if windowDays < 7 {
+		windowDays = 7
+	}
+	from := now.AddDate(0, 0, -windowDays)
+	fromStr := from.Format("2006-01-02T15:04:05")
+	weeks := windowDays / 7
+
+	type acc struct {
+		article string
+		revenue float64
+		units   int
+		weekly  []float64
+	}
+	byNm := map[int64]*acc{}
+	for _, s := range sales {
+		date := s.String("date")
+		if date < fromStr {
+			continue
+		}
+		sign := 0
+		switch id := s.String("saleID"); {
+		case strings.HasPrefix(id, "S"):
+			sign = 1
+		case strings.HasPrefix(id, "R"):
+			sign = -1
+		default:
+			continue
+		}
+
+		nmID := s.Int64("nmId")
+		a := byNm[nmID]
+		if a == nil {
+			a = &acc{article: s.String("supplierArticle"), weekly: make([]float64, weeks)}
+			byNm[nmID] = a
+		}
+		a.revenue += float64(sign) * math.Abs(s.Float("priceWithDisc"))
+		a.units += sign
+
+		// неделя 0 — последние 7 дней до now; неполная неделя в начале окна отбрасывается,
+		// иначе её продажи увеличили бы одну из корзин и исказили вариацию
+		if t, err := time.ParseInLocation("2006-01-02T15:04:05", date, now.Location()); err == nil {
+			if w := int(now.Sub(t).Hours() / (24 * 7)); w >= 0 && w < weeks {
+				a.weekly[w] += float64(sign)
+			}
+		}
+	}
+
+	items := make([]ABCXYZItem, 0, len(byNm))
+	total := 0.0
+	for nmID, a := range byNm {
+		if a.units <= 0 && a.revenue <= 0 {
+			continue
+		}
+		revenue := math.Max(0, a.revenue)
+		total += revenue
+		items = append(items, ABCXYZItem{
+			NmID:            nmID,
+			SupplierArticle: a.article,
+			Revenue:         round2(revenue),
+			Units:           a.units,
+			CV:              round2(variation(a.weekly)),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Revenue != items[j].Revenue {
+			return items[i].Revenue > items[j].Revenue
+		}
+		return items[i].NmID < items[j].NmID
+	})
+
+	m := ABCXYZMatrix{
+		WindowDays: windowDays,
+		DateFrom:   from.Format("2006-01-02"),
+		DateTo:     now.Format("2006-01-02"),
+		Cells:      map[string]ABCXYZCell{},
+	}
+	for _, abc := range []string{"A", "B", "C"} {
+		for _, xyz := range []string{"X", "Y", "Z"} {
+			m.Cells[abc+xyz] = ABCXYZCell{}
+		}
+	}
+
+	cumulative := 0.0
+	for i := range items {
+		it := &items[i]
+		if total > 0 {
+			it.RevenueShare = round2(it.Revenue / total * 100)
+		}
+		// класс определяется по доле до добавления товара, чтобы лидер с долей >80% оставался в A
+		switch {
+		case cumulative < abcBoundA:
+			it.ABC = "A"
+		case cumulative < abcBoundB:
+			it.ABC = "B"
+		default:
+			it.ABC = "C"
+		}
+		if total > 0 {
+			cumulative += it.Revenue / total * 100
+		}
+		it.CumulativeShare = round2(cumulative)
+
+		switch {
+		case it.CV <= xyzBoundX:
+			it.XYZ = "X"
+		case it.CV <= xyzBoundY:
+			it.XYZ = "Y"
+		default:
+			it.XYZ = "Z"
+		}
+		it.Class = it.ABC + it.XYZ
+
+		cell := m.Cells[it.Class]
+		cell.Count++
+		cell.Revenue = round2(cell.Revenue + it.Revenue)
+		if total > 0 {
+			cell.RevenueShare = round2(cell.Revenue / total * 100)
+		}
+		m.Cells[it.Class] = cell
+	}
+	m.Items = items
+	return m
+}
+
+// variation — коэффициент вариации ряда, %; для ряда с нулевым средним — 100
+func variation(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	if mean <= 0 {
+		return 100
+	}
+	sq := 0.0
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(sq/float64(len(xs))) / mean * 100
+}
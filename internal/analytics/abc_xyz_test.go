@@ -0,0 +1,168 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+func testSale(now time.Time, hoursAgo float64, saleID string, nmID int64, price float64) api.WBRecord {
+	return api.WBRecord{
+		"date":          now.Add(-time.Duration(hoursAgo * float64(time.Hour))).Format("2006-01-02T15:04:05"),
+		"saleID":        saleID,
+		"nmId":          float64(nmID),
+		"priceWithDisc": price,
+	}
+}
+
+// dailySales — по одной продаже товара в день за days дней до now
+func dailySales(now time.Time, nmID int64, days int, price float64) []api.WBRecord {
+	out := make([]api.WBRecord, 0, days)
+	for d := 0; d < days; d++ {
+		out = append(out, testSale(now, float64(d)*24+1, "S1", nmID, price))
+	}
+	return out
+}
+
+func TestVariation(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"empty", nil, 0},
+		{"constant", []float64{5, 5, 5, 5}, 0},
+		{"zero mean", []float64{0, 0, 0}, 100},
+		{"negative mean", []float64{-1, 0}, 100},
+		{"two values", []float64{1, 3}, 50},
+		{"one spike", []float64{0, 0, 0, 4}, math.Sqrt(3) * 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := variation(tt.xs); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("variation(%v) = %v, want %v", tt.xs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestABCXYZClasses(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		sales      []api.WBRecord
+		windowDays int
+		want       map[int64]string // nmId → класс; товаров в результате должно быть ровно столько
+	}{
+		{
+			// 90 дней = 12 полных недель + 6 дней; остаток не должен попадать в одну из корзин
+			name:       "stable daily demand over 90 days is X",
+			sales:      dailySales(now, 1, 90, 100),
+			windowDays: 90,
+			want:       map[int64]string{1: "AX"},
+		},
+		{
+			name:       "stable daily demand over 30 days is X",
+			sales:      dailySales(now, 1, 30, 100),
+			windowDays: 30,
+			want:       map[int64]string{1: "AX"},
+		},
+		{
+			// доли выручки 70/20/6/4: класс — по накопленной доле до товара (0, 70, 90, 96)
+			name: "abc bounds by cumulative share",
+			sales: append(append(append(
+				dailySales(now, 1, 28, 250),
+				dailySales(now, 2, 28, 2000.0/28)...),
+				dailySales(now, 3, 28, 600.0/28)...),
+				dailySales(now, 4, 28, 400.0/28)...),
+			windowDays: 28,
+			want:       map[int64]string{1: "AX", 2: "AX", 3: "BX", 4: "CX"},
+		},
+		{
+			// одна продажа за 4 недели: недельный ряд 1,0,0,0 — CV ≈ 173%
+			name:       "single sale is Z",
+			sales:      []api.WBRecord{testSale(now, 30, "S1", 7, 500)},
+			windowDays: 28,
+			want:       map[int64]string{7: "AZ"},
+		},
+		{
+			// недели 3,2,3,2 (от now назад): CV = 0.5/2.5 = 20%
+			name: "moderate weekly swings are Y",
+			sales: func() []api.WBRecord {
+				out := []api.WBRecord{}
+				for w, n := range []int{3, 2, 3, 2} {
+					for i := 0; i < n; i++ {
+						out = append(out, testSale(now, float64(w*7*24+24+i), "S1", 5, 100))
+					}
+				}
+				return out
+			}(),
+			windowDays: 28,
+			want:       map[int64]string{5: "AY"},
+		},
+		{
+			name: "fully returned product is excluded",
+			sales: []api.WBRecord{
+				testSale(now, 48, "S1", 1, 100),
+				testSale(now, 24, "R1", 1, -100),
+				testSale(now, 24, "S2", 2, 100),
+			},
+			windowDays: 28,
+			want:       map[int64]string{2: "AZ"},
+		},
+		{
+			name: "sales outside window and unknown ids are ignored",
+			sales: append(dailySales(now, 1, 14, 100),
+				testSale(now, 15*24, "S1", 9, 100),
+				testSale(now, 24, "D1", 9, 100),
+			),
+			windowDays: 14,
+			want:       map[int64]string{1: "AX"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ABCXYZ(tt.sales, tt.windowDays, now)
+			if len(m.Items) != len(tt.want) {
+				t.Fatalf("got %d items, want %d: %+v", len(m.Items), len(tt.want), m.Items)
+			}
+			for _, it := range m.Items {
+				want, ok := tt.want[it.NmID]
+				if !ok {
+					t.Errorf("unexpected nmId %d in result", it.NmID)
+					continue
+				}
+				if it.Class != want {
+					t.Errorf("nmId %d: class %s (cv %.2f, cumulative %.2f), want %s", it.NmID, it.Class, it.CV, it.CumulativeShare, want)
+				}
+			}
+		})
+	}
+}
+
+func TestABCXYZMatrixCells(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	sales := append(dailySales(now, 1, 28, 300), testSale(now, 30, "S1", 2, 100))
+
+	m := ABCXYZ(sales, 28, now)
+	if len(m.Cells) != 9 {
+		t.Fatalf("got %d cells, want 9", len(m.Cells))
+	}
+	if c := m.Cells["AX"]; c.Count != 1 || c.Revenue != 8400 {
+		t.Errorf("AX cell = %+v, want 1 item with revenue 8400", c)
+	}
+	if c := m.Cells["CZ"]; c.Count != 1 || c.Revenue != 100 {
+		t.Errorf("CZ cell = %+v, want 1 item with revenue 100", c)
+	}
+	total := 0.0
+	for _, c := range m.Cells {
+		total += c.RevenueShare
+	}
+	if math.Abs(total-100) > 0.05 {
+		t.Errorf("cell revenue shares sum to %.2f, want 100", total)
+	}
+}
@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"sort"
+
+	"wildberriesapi/internal/api"
+)
+
+// WarehouseOption — ближайшая самая дешёвая дата приёмки на складе
+type WarehouseOption struct {
+	WarehouseID     int64   `json:"warehouse_id"`
+	WarehouseName   string  `json:"warehouse_name"`
+	Date            string  `json:"date"`
+	Coefficient     float64 `json:"coefficient"`     // 0 — бесплатная приёмка
+	DeliveryBase    float64 `json:"delivery_base"`   // логистика первого литра, ₽
+	DeliveryLiter   float64 `json:"delivery_liter"`  // логистика каждого следующего литра, ₽
+	StorageBase     float64 `json:"storage_base"`    // хранение первого литра в день, ₽
+	StorageLiter    float64 `json:"storage_liter"`   // хранение следующего литра в день, ₽
+	FreeDates       int     `json:"free_dates"`      // дней с бесплатной приёмкой в периоде
+	AvailableDates  int     `json:"available_dates"` // дней с открытой приёмкой в периоде
+	IsSortingCenter bool    `json:"is_sorting_center"`
+}
+
+// CheapestWarehouses выбирает склады с открытой приёмкой для типа упаковки boxTypeID в [from, to] (YYYY-MM-DD).
+// Для склада берётся дата с минимальным коэффициентом (при равенстве — самая ранняя);
+// склады сортируются по коэффициенту, затем по стоимости логистики.
+func CheapestWarehouses(coefs []api.AcceptanceCoefficient, from, to string, boxTypeID, limit int) []WarehouseOption {
+	best := map[int64]*WarehouseOption{}
+
+	for _, c := range coefs {
+		date := c.Date
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		if c.BoxTypeID != boxTypeID || date < from || date > to || !c.Available() {
+			continue
+		}
+
+		o := best[c.WarehouseID]
+		if o == nil {
+			o = &WarehouseOption{WarehouseID: c.WarehouseID, WarehouseName: c.WarehouseName, Coefficient: -1}
+			best[c.WarehouseID] = o
+		}
+		o.AvailableDates++
+		if c.Coefficient == 0 {
+			o.FreeDates++
+		}
+		if o.Coefficient >= 0 && (c.Coefficient > o.Coefficient || (c.Coefficient == o.Coefficient && date >= o.Date)) {
+			continue
+		}
+
+		o.Date, o.Coefficient, o.IsSortingCenter = date, c.Coefficient, c.IsSortingCenter
+		o.DeliveryBase, _ = api.ParseWBNumber(c.DeliveryBaseLiter)
+		o.DeliveryLiter, _ = api.ParseWBNumber(c.DeliveryAdditionalLiter)
+		o.StorageBase, _ = api.ParseWBNumber(c.StorageBaseLiter)
+		o.StorageLiter, _ = api.ParseWBNumber(c.StorageAdditionalLiter)
+	}
+
+	out := make([]WarehouseOption, 0, len(best))
+	for _, o := range best {
+		out = append(out, *o)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Coefficient != out[j].Coefficient {
+			return out[i].Coefficient < out[j].Coefficient
+		}
+		if out[i].DeliveryBase != out[j].DeliveryBase {
+			return out[i].DeliveryBase < out[j].DeliveryBase
+		}
+		return out[i].Date < out[j].Date
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
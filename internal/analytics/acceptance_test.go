@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"testing"
+
+	"wildberriesapi/internal/api"
+)
+
+func testCoef(whID int64, name, date string, coef float64, deliveryBase string) api.AcceptanceCoefficient {
+	return api.AcceptanceCoefficient{
+		Date:              date,
+		Coefficient:       coef,
+		WarehouseID:       whID,
+		WarehouseName:     name,
+		AllowUnload:       true,
+		BoxTypeID:         2,
+		DeliveryBaseLiter: deliveryBase,
+	}
+}
+
+func TestCheapestWarehouses(t *testing.T) {
+	closed := testCoef(4, "D", "2026-10-21", 0, "10")
+	closed.AllowUnload = false
+	pallet := testCoef(5, "P", "2026-10-21", 0, "10")
+	pallet.BoxTypeID = 5
+
+	coefs := []api.AcceptanceCoefficient{
+		// A: минимальный коэффициент 0 дважды — берётся самая ранняя из таких дат
+		testCoef(1, "A", "2026-10-21T00:00:00Z", 1, "50"),
+		testCoef(1, "A", "2026-10-23T00:00:00Z", 0, "50"),
+		testCoef(1, "A", "2026-10-22T00:00:00Z", 0, "50"),
+		// B и C: коэффициент и логистика равны — раньше идёт склад с более ранней датой
+		testCoef(2, "B", "2026-10-21", 0, "40"),
+		testCoef(3, "C", "2026-10-20", 0, "40"),
+		testCoef(6, "E", "2026-10-21", 2, "1"),
+		// закрытая приёмка, -1, другой тип упаковки и даты вне периода не учитываются
+		closed,
+		testCoef(7, "F", "2026-10-21", -1, "1"),
+		pallet,
+		testCoef(8, "G", "2026-10-19", 0, "1"),
+		testCoef(8, "G", "2026-10-27", 0, "1"),
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string // склад@дата по порядку
+	}{
+		{
+			name: "sorted by coefficient, delivery, date",
+			want: []string{"C@2026-10-20", "B@2026-10-21", "A@2026-10-22", "E@2026-10-21"},
+		},
+		{
+			name:  "limit",
+			limit: 2,
+			want:  []string{"C@2026-10-20", "B@2026-10-21"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheapestWarehouses(coefs, "2026-10-20", "2026-10-26", 2, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d warehouses %+v, want %v", len(got), got, tt.want)
+			}
+			for i, o := range got {
+				if s := o.WarehouseName + "@" + o.Date; s != tt.want[i] {
+					t.Errorf("position %d: %s, want %s", i, s, tt.want[i])
+				}
+			}
+		})
+	}
+
+	for _, o := range CheapestWarehouses(coefs, "2026-10-20", "2026-10-26", 2, 0) {
+		if o.WarehouseName != "A" {
+			continue
+		}
+		if o.AvailableDates != 3 || o.FreeDates != 2 || o.Coefficient != 0 || o.DeliveryBase != 50 {
+			t.Errorf("A = %+v, want 3 available dates, 2 free, coefficient 0, delivery base 50", o)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// Разрезы выкупов
+const (
+	BuyoutByNm        = "nm"
+	BuyoutByRegion    = "region"
+	BuyoutByWarehouse = "warehouse"
+)
+
+// BuyoutRate — выкупы и возвраты по заказам за окно в одном разрезе.
+// Заказы без продажи и без отмены считаются ещё не завершёнными (pending) и не входят в знаменатель процента выкупа.
+type BuyoutRate struct {
+	By         string `json:"by"`  // nm, region, warehouse
+	Key        string `json:"key"` // nmId, регион или склад
+	WindowDays int    `json:"window_days"`
+	DateFrom   string `json:"date_from"`
+	DateTo     string `json:"date_to"`
+
+	Orders    int `json:"orders"`
+	Cancelled int `json:"cancelled"`
+	Buyouts   int `json:"buyouts"` // заказы с продажей (saleID на S)
+	Returns   int `json:"returns"` // выкупы, по которым прошёл возврат (saleID на R)
+	Pending   int `json:"pending"`
+
+	BuyoutPct    float64 `json:"buyout_pct"`     // выкупы / завершённые заказы
+	ReturnPct    float64 `json:"return_pct"`     // возвраты / выкупы
+	NetBuyoutPct float64 `json:"net_buyout_pct"` // (выкупы - возвраты) / завершённые заказы
+}
+
+// ValidBuyoutBy проверяет разрез выкупов
+func ValidBuyoutBy(by string) error {
+	switch by {
+	case BuyoutByNm, BuyoutByRegion, BuyoutByWarehouse:
+		return nil
+	}
+	return fmt.Errorf("invalid param: by (nm, region or warehouse)")
+}
+
+// BuyoutRates сводит заказы за последние windowDays дней до now с продажами и возвратами по токену и srid
+// и считает проценты выкупа и возврата в разрезе by. Результат отсортирован по числу заказов.
+func BuyoutRates(orders, sales []api.WBRecord, by string, windowDays int, now time.Time) []BuyoutRate {
+	if windowDays < 1 {
+		windowDays = 1
+	}
+	from := now.AddDate(0, 0, -windowDays)
+	fromStr := from.Format("2006-01-02T15:04:05")
+
+	sold, returned := map[string]bool{}, map[string]bool{}
+	for _, s := range sales {
+		if s.String("srid") == "" {
+			continue
+		}
+		srid := saleJoinKey(s)
+		switch id := s.String("saleID"); {
+		case strings.HasPrefix(id, "S"):
+			sold[srid] = true
+		case strings.HasPrefix(id, "R"):
+			returned[srid] = true
+		}
+	}
+
+	byKey := map[string]*BuyoutRate{}
+	for _, o := range orders {
+		if o.String("date") < fromStr {
+			continue
+		}
+		key := buyoutKey(o, by)
+		r := byKey[key]
+		if r == nil {
+			r = &BuyoutRate{By: by, Key: key, WindowDays: windowDays,
+				DateFrom: from.Format("2006-01-02"), DateTo: now.Format("2006-01-02")}
+			byKey[key] = r
+		}
+
+		srid := saleJoinKey(o)
+		r.Orders++
+		switch {
+		case sold[srid]:
+			r.Buyouts++
+			if returned[srid] {
+				r.Returns++
+			}
+		case o.Bool("isCancel"):
+			r.Cancelled++
+		default:
+			r.Pending++
+		}
+	}
+
+	out := make([]BuyoutRate, 0, len(byKey))
+	for _, r := range byKey {
+		settled := r.Orders - r.Pending
+		r.BuyoutPct = percent(r.Buyouts, settled)
+		r.ReturnPct = percent(r.Returns, r.Buyouts)
+		r.NetBuyoutPct = percent(r.Buyouts-r.Returns, settled)
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Orders != out[j].Orders {
+			return out[i].Orders > out[j].Orders
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// saleJoinKey — ключ связи заказа с продажей: srid уникален только в пределах кабинета продавца
+func saleJoinKey(r api.WBRecord) string {
+	return strconv.Itoa(r.TokenIdx()) + "|" + r.String("srid")
+}
+
+func buyoutKey(o api.WBRecord, by string) string {
+	switch by {
+	case BuyoutByRegion:
+		if v := o.String("regionName"); v != "" {
+			return v
+		}
+		return orderRegion(o)
+	case BuyoutByWarehouse:
+		return o.String("warehouseName")
+	}
+	return strconv.FormatInt(o.Int64("nmId"), 10)
+}
@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+func testBuyoutOrder(now time.Time, daysAgo, tokenIdx int, nmID int64, srid string, cancelled bool) api.WBRecord {
+	return api.WBRecord{
+		"date":      now.AddDate(0, 0, -daysAgo).Format("2006-01-02T15:04:05"),
+		"token_idx": float64(tokenIdx),
+		"nmId":      float64(nmID),
+		"srid":      srid,
+		"isCancel":  cancelled,
+	}
+}
+
+func testBuyoutSale(tokenIdx int, srid, saleID string) api.WBRecord {
+	return api.WBRecord{"token_idx": float64(tokenIdx), "srid": srid, "saleID": saleID}
+}
+
+func TestBuyoutRates(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	orders := []api.WBRecord{
+		// nm 1: 2 выкупа (один с возвратом), 1 отмена, 2 в пути
+		testBuyoutOrder(now, 1, 1, 1, "a", false),
+		testBuyoutOrder(now, 2, 1, 1, "b", false),
+		testBuyoutOrder(now, 3, 1, 1, "c", true),
+		testBuyoutOrder(now, 4, 1, 1, "d", false),
+		testBuyoutOrder(now, 5, 1, 1, "e", false), // продажа с тем же srid есть только в другом кабинете
+		testBuyoutOrder(now, 40, 1, 1, "old", false),
+		// nm 2: выкуп во втором кабинете
+		testBuyoutOrder(now, 1, 2, 2, "a", false),
+		// nm 3: только отмена
+		testBuyoutOrder(now, 1, 1, 3, "f", true),
+		// nm 4: только заказы в пути
+		testBuyoutOrder(now, 1, 1, 4, "g", false),
+	}
+	sales := []api.WBRecord{
+		testBuyoutSale(1, "a", "S1"),
+		testBuyoutSale(1, "b", "S2"),
+		testBuyoutSale(1, "b", "R2"),
+		testBuyoutSale(2, "e", "S3"),
+		testBuyoutSale(2, "a", "S4"),
+		testBuyoutSale(1, "old", "S5"),
+		testBuyoutSale(1, "", "S6"),
+	}
+
+	tests := []struct {
+		key                                          string
+		orders, buyouts, returns, cancelled, pending int
+		buyoutPct, returnPct, netBuyoutPct           float64
+	}{
+		{"1", 5, 2, 1, 1, 2, 66.67, 50, 33.33},
+		{"2", 1, 1, 0, 0, 0, 100, 0, 100},
+		{"3", 1, 0, 0, 1, 0, 0, 0, 0},
+		{"4", 1, 0, 0, 0, 1, 0, 0, 0},
+	}
+
+	got := BuyoutRates(orders, sales, BuyoutByNm, 30, now)
+	if len(got) != len(tests) {
+		t.Fatalf("got %d rates %+v, want %d", len(got), got, len(tests))
+	}
+	for i, tt := range tests {
+		r := got[i]
+		if r.Key != tt.key {
+			t.Errorf("position %d: key %s, want %s", i, r.Key, tt.key)
+			continue
+		}
+		if r.Orders != tt.orders || r.Buyouts != tt.buyouts || r.Returns != tt.returns || r.Cancelled != tt.cancelled || r.Pending != tt.pending {
+			t.Errorf("nm %s: orders/buyouts/returns/cancelled/pending = %d/%d/%d/%d/%d, want %d/%d/%d/%d/%d",
+				r.Key, r.Orders, r.Buyouts, r.Returns, r.Cancelled, r.Pending,
+				tt.orders, tt.buyouts, tt.returns, tt.cancelled, tt.pending)
+		}
+		if r.BuyoutPct != tt.buyoutPct || r.ReturnPct != tt.returnPct || r.NetBuyoutPct != tt.netBuyoutPct {
+			t.Errorf("nm %s: buyout/return/net = %v/%v/%v, want %v/%v/%v",
+				r.Key, r.BuyoutPct, r.ReturnPct, r.NetBuyoutPct, tt.buyoutPct, tt.returnPct, tt.netBuyoutPct)
+		}
+		if r.DateFrom != "2026-09-19" || r.DateTo != "2026-10-19" || r.WindowDays != 30 {
+			t.Errorf("nm %s: window %s..%s (%d days), want 2026-09-19..2026-10-19 (30 days)", r.Key, r.DateFrom, r.DateTo, r.WindowDays)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package analytics — расчёты поверх выгрузок WB: воронка, экономика, прогнозы остатков.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"wildberriesapi/internal/api"
+)
+
+// Группировки воронки
+const (
+	GroupBySubject = "subject"
+	GroupByBrand   = "brand"
+	GroupByTag     = "tag"
+)
+
+// FunnelRow — воронка группы за период (или за день) с конверсиями в процентах
+type FunnelRow struct {
+	Group   string `json:"group"`
+	GroupID int    `json:"group_id,omitempty"`
+	Date    string `json:"date,omitempty"` // пусто — итог за период
+
+	Views      int     `json:"views"`
+	Carts      int     `json:"carts"`
+	Orders     int     `json:"orders"`
+	Buyouts    int     `json:"buyouts"`
+	OrdersSum  float64 `json:"orders_sum"`
+	BuyoutsSum float64 `json:"buyouts_sum"`
+
+	ViewToCart    float64 `json:"view_to_cart"`    // просмотр → корзина, %
+	CartToOrder   float64 `json:"cart_to_order"`   // корзина → заказ, %
+	OrderToBuyout float64 `json:"order_to_buyout"` // заказ → выкуп, %
+	ViewToOrder   float64 `json:"view_to_order"`   // сквозная конверсия, %
+}
+
+// ValidGroupBy проверяет группировку воронки
+func ValidGroupBy(by string) error {
+	switch by {
+	case GroupBySubject, GroupByBrand, GroupByTag:
+		return nil
+	}
+	return fmt.Errorf("unknown group %q (subject, brand or tag)", by)
+}
+
+// GroupFunnel сворачивает групповую статистику WB (предмет + бренд + ярлык) до одного измерения by.
+// daily = true — строка на группу и день, иначе — итог за весь период. Строки отсортированы по сумме заказов.
+func GroupFunnel(stats []api.NMGroupStats, by string, daily bool) []FunnelRow {
+	type key struct {
+		group string
+		id    int
+		date  string
+	}
+	rows := map[key]*FunnelRow{}
+
+	for _, s := range stats {
+		k := key{}
+		switch by {
+		case GroupBySubject:
+			k.group, k.id = s.SubjectName, s.SubjectID
+		case GroupByBrand:
+			k.group = s.BrandName
+		case GroupByTag:
+			k.group, k.id = s.TagName, s.TagID
+		}
+		if daily {
+			k.date = s.Date
+		}
+
+		r := rows[k]
+		if r == nil {
+			r = &FunnelRow{Group: k.group, GroupID: k.id, Date: k.date}
+			rows[k] = r
+		}
+		r.Views += s.OpenCardCount
+		r.Carts += s.AddToCartCount
+		r.Orders += s.OrdersCount
+		r.Buyouts += s.BuyoutsCount
+		r.OrdersSum += s.OrdersSumRub
+		r.BuyoutsSum += s.BuyoutsSumRub
+	}
+
+	out := make([]FunnelRow, 0, len(rows))
+	for _, r := range rows {
+		r.ViewToCart = percent(r.Carts, r.Views)
+		r.CartToOrder = percent(r.Orders, r.Carts)
+		r.OrderToBuyout = percent(r.Buyouts, r.Orders)
+		r.ViewToOrder = percent(r.Orders, r.Views)
+		r.OrdersSum = round2(r.OrdersSum)
+		r.BuyoutsSum = round2(r.BuyoutsSum)
+		out = append(out, *r)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Date != out[j].Date {
+			return out[i].Date < out[j].Date
+		}
+		if out[i].OrdersSum != out[j].OrdersSum {
+			return out[i].OrdersSum > out[j].OrdersSum
+		}
+		return out[i].Group < out[j].Group
+	})
+	return out
+}
+
+// percent — доля part от total в процентах (0, если total = 0)
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(float64(part) / float64(total) * 100)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
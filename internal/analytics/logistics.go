@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"strings"
+
+	"wildberriesapi/internal/api"
+)
+
+// Типы тарифов для расчёта логистики
+const (
+	LogisticsBox    = "box"
+	LogisticsPallet = "pallet"
+)
+
+// LogisticsQuote — расчёт логистики и хранения одной единицы товара на складе.
+// Поля *float64 — nil, если WB не указал тариф склада для этого вида услуги.
+type LogisticsQuote struct {
+	WarehouseName  string   `json:"warehouse_name"`
+	Type           string   `json:"type"` // box, pallet
+	Liters         float64  `json:"liters"`
+	DeliveryCost   *float64 `json:"delivery_cost"`    // логистика до покупателя, ₽
+	StorageDay     *float64 `json:"storage_day"`      // хранение в день, ₽ (для паллет — за паллету)
+	DeliveryCoef   *float64 `json:"delivery_coef"`    // коэффициент логистики склада, %
+	StorageCoef    *float64 `json:"storage_coef"`     // коэффициент хранения склада, %
+	FBSDeliveryFee *float64 `json:"fbs_delivery_fee"` // логистика FBS (только короба), ₽
+}
+
+// CalcBoxLogistics считает стоимость по коробному тарифу склада warehouse (без учёта регистра);
+// false — склад не найден в тарифах
+func CalcBoxLogistics(tariffs []api.TariffsBox, warehouse string, liters float64) (LogisticsQuote, bool) {
+	t, ok := FindBoxTariff(tariffs, warehouse)
+	if !ok {
+		return LogisticsQuote{}, false
+	}
+	q := LogisticsQuote{
+		WarehouseName: t.WarehouseName,
+		Type:          LogisticsBox,
+		Liters:        liters,
+		DeliveryCoef:  t.DeliveryCoefPct,
+		StorageCoef:   t.StorageCoefPct,
+	}
+	if v, ok := t.DeliveryCost(liters); ok {
+		q.DeliveryCost = &v
+	}
+	if v, ok := t.StorageCostPerDay(liters); ok {
+		q.StorageDay = &v
+	}
+	if t.DeliveryMarketplaceBase != nil {
+		fbs := api.BoxTariff{DeliveryBase: t.DeliveryMarketplaceBase, DeliveryLiter: t.DeliveryMarketplaceLiter}
+		if v, ok := fbs.DeliveryCost(liters); ok {
+			q.FBSDeliveryFee = &v
+		}
+	}
+	return q, true
+}
+
+// FindBoxTariff ищет коробный тариф склада по названию без учёта регистра
+func FindBoxTariff(tariffs []api.TariffsBox, warehouse string) (api.BoxTariff, bool) {
+	for _, b := range tariffs {
+		for _, t := range b.Data.WarehouseList {
+			if strings.EqualFold(strings.TrimSpace(t.WarehouseName), strings.TrimSpace(warehouse)) {
+				return t, true
+			}
+		}
+	}
+	return api.BoxTariff{}, false
+}
+
+// CalcPalletLogistics считает стоимость по паллетному тарифу склада warehouse (без учёта регистра);
+// false — склад не найден в тарифах
+func CalcPalletLogistics(tariffs []api.TariffsPallet, warehouse string, liters float64) (LogisticsQuote, bool) {
+	for _, p := range tariffs {
+		for _, t := range p.Data.WarehouseList {
+			if !strings.EqualFold(strings.TrimSpace(t.WarehouseName), strings.TrimSpace(warehouse)) {
+				continue
+			}
+			q := LogisticsQuote{
+				WarehouseName: t.WarehouseName,
+				Type:          LogisticsPallet,
+				Liters:        liters,
+				StorageDay:    t.StoragePallet,
+				DeliveryCoef:  t.DeliveryCoefPct,
+				StorageCoef:   t.StorageCoefPct,
+			}
+			if v, ok := t.DeliveryCost(liters); ok {
+				q.DeliveryCost = &v
+			}
+			return q, true
+		}
+	}
+	return LogisticsQuote{}, false
+}
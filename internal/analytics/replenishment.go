@@ -0,0 +1,247 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+const (
+	// minRegionShare — склад считается обслуживающим округ, если отгрузил туда не меньше этой доли заказов
+	minRegionShare = 0.05
+	// sellerWarehouseType — warehouseType заказов FBS; такие склады в план поставок FBW не попадают
+	sellerWarehouseType = "Склад продавца"
+)
+
+// SupplyLine — рекомендация поставки товара на склад WB
+type SupplyLine struct {
+	NmID            int64    `json:"nm_id"`
+	SupplierArticle string   `json:"supplier_article"`
+	WarehouseName   string   `json:"warehouse_name"`
+	Regions         []string `json:"regions"`        // округа, спрос которых закрывает склад
+	OrdersPerDay    float64  `json:"orders_per_day"` // спрос округов, отнесённый на склад
+	Stock           int      `json:"stock"`          // текущий остаток на складе
+	// NetworkStock и InWayFromClient — остаток товара на всех складах WB и возвраты в пути на склады
+	NetworkStock    int     `json:"network_stock"`
+	InWayFromClient int     `json:"in_way_from_client"`
+	TargetQty       int     `json:"target_qty"` // запас на TargetDays дней
+	Quantity        int     `json:"quantity"`   // к поставке
+	VolumeLiters    float64 `json:"volume_liters"`
+	// DeliveryCost — логистика единицы со склада; nil — нет тарифа склада или габаритов товара
+	DeliveryCost   *float64 `json:"delivery_cost"`
+	LogisticsTotal float64  `json:"logistics_total"` // логистика всей поставки до покупателей
+}
+
+// ReplenishmentParams — параметры плана поставок
+type ReplenishmentParams struct {
+	TargetDays float64 // целевой запас в днях
+	WindowDays int     // окно для скорости заказов
+	Now        time.Time
+}
+
+// PlanReplenishment рассчитывает план поставок FBW. Спрос товара делится по федеральным округам покупателей;
+// спрос округа относится на самый дешёвый по логистике склад среди тех, что реально отгружают в этот округ
+// (по всем заказам окна, не меньше 5% заказов округа). К поставке — запас на TargetDays дней минус остаток склада,
+// но в сумме по товару не больше, чем не хватает всей сети: запас на TargetDays дней минус остатки на всех складах WB
+// и возвраты в пути. Урезанное количество распределяется между складами пропорционально их нехватке.
+// volumes — объём единицы товара в литрах по nmId.
+func PlanReplenishment(stocks, orders []api.WBRecord, tariffs []api.TariffsBox, volumes map[int64]float64, p ReplenishmentParams) []SupplyLine {
+	if p.WindowDays < 1 {
+		p.WindowDays = 1
+	}
+	from := p.Now.AddDate(0, 0, -p.WindowDays).Format("2006-01-02T15:04:05")
+
+	boxByName := map[string]api.BoxTariff{}
+	for _, b := range tariffs {
+		for _, t := range b.Data.WarehouseList {
+			boxByName[strings.ToLower(strings.TrimSpace(t.WarehouseName))] = t
+		}
+	}
+
+	type nmDemand struct {
+		article  string
+		byRegion map[string]int
+	}
+	demand := map[int64]*nmDemand{}
+	// regionWarehouses — округ → склад → заказов по всем товарам
+	regionWarehouses := map[string]map[string]int{}
+	regionTotal := map[string]int{}
+
+	for _, o := range orders {
+		if o.Bool("isCancel") || o.String("date") < from {
+			continue
+		}
+		region := orderRegion(o)
+		wh := o.String("warehouseName")
+		nmID := o.Int64("nmId")
+
+		d := demand[nmID]
+		if d == nil {
+			d = &nmDemand{article: o.String("supplierArticle"), byRegion: map[string]int{}}
+			demand[nmID] = d
+		}
+		d.byRegion[region]++
+
+		if o.String("warehouseType") == sellerWarehouseType {
+			continue
+		}
+		if regionWarehouses[region] == nil {
+			regionWarehouses[region] = map[string]int{}
+		}
+		regionWarehouses[region][wh]++
+		regionTotal[region]++
+	}
+
+	stockAt := map[string]int{}
+	networkStock, returning := map[int64]int{}, map[int64]int{}
+	for _, s := range stocks {
+		nmID := s.Int64("nmId")
+		key := s.String("warehouseName") + "|" + strconv.FormatInt(nmID, 10)
+		stockAt[key] += int(s.Float("quantity"))
+		networkStock[nmID] += int(s.Float("quantity"))
+		returning[nmID] += int(s.Float("inWayFromClient"))
+	}
+
+	out := make([]SupplyLine, 0)
+	for nmID, d := range demand {
+		volume := volumes[nmID]
+		lines := map[string]*SupplyLine{}
+
+		for region, cnt := range d.byRegion {
+			wh := cheapestWarehouse(regionWarehouses[region], regionTotal[region], boxByName, volume)
+			if wh == "" {
+				// в округ отгружают только склады продавца
+				continue
+			}
+			line := lines[wh]
+			if line == nil {
+				line = &SupplyLine{NmID: nmID, SupplierArticle: d.article, WarehouseName: wh, VolumeLiters: volume}
+				if t, ok := boxByName[strings.ToLower(strings.TrimSpace(wh))]; ok && volume > 0 {
+					if v, ok := t.DeliveryCost(volume); ok {
+						line.DeliveryCost = &v
+					}
+				}
+				lines[wh] = line
+			}
+			line.Regions = append(line.Regions, region)
+			line.OrdersPerDay += float64(cnt) / float64(p.WindowDays)
+		}
+
+		demandPerDay := 0.0
+		short := make([]*SupplyLine, 0, len(lines))
+		deficit := 0
+		for wh, line := range lines {
+			demandPerDay += line.OrdersPerDay
+			sort.Strings(line.Regions)
+			line.OrdersPerDay = round2(line.OrdersPerDay)
+			line.Stock = stockAt[wh+"|"+strconv.FormatInt(nmID, 10)]
+			line.NetworkStock = networkStock[nmID]
+			line.InWayFromClient = returning[nmID]
+			line.TargetQty = int(math.Ceil(line.OrdersPerDay * p.TargetDays))
+			line.Quantity = line.TargetQty - line.Stock
+			if line.Quantity > 0 {
+				short = append(short, line)
+				deficit += line.Quantity
+			}
+		}
+
+		// остаток на других складах и возвраты тоже закрывают спрос — сеть не перезатариваем
+		networkNeed := int(math.Ceil(demandPerDay*p.TargetDays)) - networkStock[nmID] - returning[nmID]
+		if networkNeed < deficit {
+			capQuantities(short, deficit, networkNeed)
+		}
+
+		for _, line := range short {
+			if line.Quantity <= 0 {
+				continue
+			}
+			if line.DeliveryCost != nil {
+				line.LogisticsTotal = round2(*line.DeliveryCost * float64(line.Quantity))
+			}
+			out = append(out, *line)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].NmID != out[j].NmID {
+			return out[i].NmID < out[j].NmID
+		}
+		return out[i].WarehouseName < out[j].WarehouseName
+	})
+	return out
+}
+
+// capQuantities урезает поставки складов до limit единиц в сумме пропорционально нехватке (deficit — сумма Quantity);
+// остаток от округления достаётся складам с наибольшей дробной частью
+func capQuantities(lines []*SupplyLine, deficit, limit int) {
+	if limit <= 0 || deficit <= 0 {
+		for _, l := range lines {
+			l.Quantity = 0
+		}
+		return
+	}
+
+	type share struct {
+		line *SupplyLine
+		frac float64
+	}
+	shares := make([]share, 0, len(lines))
+	left := limit
+	for _, l := range lines {
+		exact := float64(l.Quantity) * float64(limit) / float64(deficit)
+		q := int(math.Floor(exact))
+		shares = append(shares, share{l, exact - float64(q)})
+		l.Quantity = q
+		left -= q
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].frac != shares[j].frac {
+			return shares[i].frac > shares[j].frac
+		}
+		return shares[i].line.WarehouseName < shares[j].line.WarehouseName
+	})
+	for i := 0; i < left && i < len(shares); i++ {
+		shares[i].line.Quantity++
+	}
+}
+
+// cheapestWarehouse выбирает склад для округа: самый дешёвый по логистике единицы среди складов,
+// отгрузивших в округ не меньше minRegionShare заказов; без тарифов — склад с наибольшим числом отгрузок
+func cheapestWarehouse(served map[string]int, total int, boxByName map[string]api.BoxTariff, volume float64) string {
+	liters := volume
+	if liters <= 0 {
+		liters = 1
+	}
+
+	best, bestCost, bestCount := "", math.Inf(1), -1
+	for wh, cnt := range served {
+		if float64(cnt) < minRegionShare*float64(total) {
+			continue
+		}
+		cost := math.Inf(1)
+		if t, ok := boxByName[strings.ToLower(strings.TrimSpace(wh))]; ok {
+			if v, ok := t.DeliveryCost(liters); ok {
+				cost = v
+			}
+		}
+		if cost < bestCost || (cost == bestCost && (cnt > bestCount || (cnt == bestCount && wh < best))) {
+			best, bestCost, bestCount = wh, cost, cnt
+		}
+	}
+	return best
+}
+
+// orderRegion — федеральный округ покупателя, если его нет — регион
+func orderRegion(o api.WBRecord) string {
+	if v := o.String("oblastOkrugName"); v != "" {
+		return v
+	}
+	if v := o.String("regionName"); v != "" {
+		return v
+	}
+	return "—"
+}
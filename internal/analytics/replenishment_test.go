@@ -0,0 +1,151 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+func TestCapQuantities(t *testing.T) {
+	tests := []struct {
+		name  string
+		qty   map[string]int // склад → нехватка
+		limit int
+		want  map[string]int
+	}{
+		{
+			name:  "proportional without remainder",
+			qty:   map[string]int{"A": 6, "B": 4},
+			limit: 5,
+			want:  map[string]int{"A": 3, "B": 2},
+		},
+		{
+			// 3.5, 2.1, 1.4 → 3, 2, 1 и остаток 1 — складу с наибольшей дробной частью
+			name:  "remainder goes to the largest fraction",
+			qty:   map[string]int{"A": 5, "B": 3, "C": 2},
+			limit: 7,
+			want:  map[string]int{"A": 4, "B": 2, "C": 1},
+		},
+		{
+			// дробные части равны — остаток по имени склада
+			name:  "equal fractions break ties by warehouse name",
+			qty:   map[string]int{"C": 1, "A": 1, "B": 1},
+			limit: 2,
+			want:  map[string]int{"A": 1, "B": 1, "C": 0},
+		},
+		{
+			name:  "limit equal to deficit keeps quantities",
+			qty:   map[string]int{"A": 7, "B": 3},
+			limit: 10,
+			want:  map[string]int{"A": 7, "B": 3},
+		},
+		{
+			name:  "no network need zeroes everything",
+			qty:   map[string]int{"A": 7, "B": 3},
+			limit: 0,
+			want:  map[string]int{"A": 0, "B": 0},
+		},
+		{
+			name:  "negative network need zeroes everything",
+			qty:   map[string]int{"A": 2},
+			limit: -5,
+			want:  map[string]int{"A": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([]*SupplyLine, 0, len(tt.qty))
+			deficit := 0
+			for wh, q := range tt.qty {
+				lines = append(lines, &SupplyLine{WarehouseName: wh, Quantity: q})
+				deficit += q
+			}
+
+			capQuantities(lines, deficit, tt.limit)
+
+			total := 0
+			for _, l := range lines {
+				if l.Quantity != tt.want[l.WarehouseName] {
+					t.Errorf("%s: quantity %d, want %d", l.WarehouseName, l.Quantity, tt.want[l.WarehouseName])
+				}
+				total += l.Quantity
+			}
+			if tt.limit > 0 && total != tt.limit {
+				t.Errorf("total %d, want exactly limit %d", total, tt.limit)
+			}
+		})
+	}
+}
+
+func testOrder(now time.Time, daysAgo int, nmID int64, warehouse, region string) api.WBRecord {
+	return api.WBRecord{
+		"date":            now.AddDate(0, 0, -daysAgo).Format("2006-01-02T15:04:05"),
+		"nmId":            float64(nmID),
+		"supplierArticle": "ART-1",
+		"warehouseName":   warehouse,
+		"warehouseType":   "Склад WB",
+		"oblastOkrugName": region,
+	}
+}
+
+func testStock(nmID int64, warehouse string, qty, returning float64) api.WBRecord {
+	return api.WBRecord{"nmId": float64(nmID), "warehouseName": warehouse, "quantity": qty, "inWayFromClient": returning}
+}
+
+func TestPlanReplenishment(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	p := ReplenishmentParams{TargetDays: 10, WindowDays: 10, Now: now}
+
+	// за 10 дней: 20 заказов в ЦФО с Коледино (2/день) и 10 в ПФО с Казани (1/день);
+	// отменённый и старый заказы не считаются
+	orders := make([]api.WBRecord, 0)
+	for i := 0; i < 20; i++ {
+		orders = append(orders, testOrder(now, i%9+1, 1, "Коледино", "Центральный"))
+	}
+	for i := 0; i < 10; i++ {
+		orders = append(orders, testOrder(now, i%9+1, 1, "Казань", "Приволжский"))
+	}
+	cancelled := testOrder(now, 1, 1, "Казань", "Приволжский")
+	cancelled["isCancel"] = true
+	orders = append(orders, cancelled, testOrder(now, 11, 1, "Казань", "Приволжский"))
+
+	tests := []struct {
+		name   string
+		stocks []api.WBRecord
+		want   map[string]int // склад → к поставке
+	}{
+		{
+			// нехватка складов 15 + 10 = 25, нехватка сети 30 - 5 = 25 — урезать нечего
+			name:   "no cap when network is short too",
+			stocks: []api.WBRecord{testStock(1, "Коледино", 5, 0)},
+			want:   map[string]int{"Коледино": 15, "Казань": 10},
+		},
+		{
+			// сеть: 30 - (5 + 10) - 3 возврата = 12; 15 и 10 урезаются до 7.2 и 4.8 → 7 и 5
+			name:   "network stock and returns cap shipments",
+			stocks: []api.WBRecord{testStock(1, "Коледино", 5, 0), testStock(1, "Тула", 10, 3)},
+			want:   map[string]int{"Коледино": 7, "Казань": 5},
+		},
+		{
+			name:   "fully stocked network ships nothing",
+			stocks: []api.WBRecord{testStock(1, "Коледино", 5, 0), testStock(1, "Тула", 40, 0)},
+			want:   map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := PlanReplenishment(tt.stocks, orders, nil, nil, p)
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines %+v, want %v", len(lines), lines, tt.want)
+			}
+			for _, l := range lines {
+				if l.Quantity != tt.want[l.WarehouseName] {
+					t.Errorf("%s: quantity %d, want %d", l.WarehouseName, l.Quantity, tt.want[l.WarehouseName])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// Статусы обеспеченности остатком
+const (
+	CoverOK  = "ok"  // запаса хватит дольше порога
+	CoverLow = "low" // запас закончится раньше порога
+	CoverOut = "out" // товара нет на складе, а заказы были
+)
+
+// StockCover — обеспеченность остатком товара на складе WB
+type StockCover struct {
+	NmID            int64   `json:"nm_id"`
+	SupplierArticle string  `json:"supplier_article"`
+	WarehouseName   string  `json:"warehouse_name"`
+	Quantity        int     `json:"quantity"`           // доступно к продаже
+	InWayToClient   int     `json:"in_way_to_client"`   // в пути к покупателю
+	InWayFromClient int     `json:"in_way_from_client"` // возвраты в пути на склад
+	Orders          int     `json:"orders"`             // заказов за окно
+	OrdersPerDay    float64 `json:"orders_per_day"`
+	// DaysOfCover — на сколько дней хватит остатка; nil — заказов за окно не было
+	DaysOfCover  *float64 `json:"days_of_cover"`
+	StockOutDate string   `json:"stock_out_date,omitempty"` // прогноз даты обнуления (YYYY-MM-DD)
+	Status       string   `json:"status"`
+}
+
+// Key — ключ товара на складе: "nmId|склад"
+func (c StockCover) Key() string {
+	return strconv.FormatInt(c.NmID, 10) + "|" + c.WarehouseName
+}
+
+// StockCoverage считает обеспеченность остатками по nmId и складу: скорость — неотменённые заказы
+// за последние windowDays дней до now, запас — остаток / скорость. Товары без остатка и без заказов пропускаются.
+// Результат отсортирован по запасу дней (сначала закончившиеся и самые срочные).
+func StockCoverage(stocks, orders []api.WBRecord, windowDays int, thresholdDays float64, now time.Time) []StockCover {
+	if windowDays < 1 {
+		windowDays = 1
+	}
+	from := now.AddDate(0, 0, -windowDays).Format("2006-01-02T15:04:05")
+
+	byKey := map[string]*StockCover{}
+	get := func(nmID int64, warehouse string) *StockCover {
+		key := strconv.FormatInt(nmID, 10) + "|" + warehouse
+		c := byKey[key]
+		if c == nil {
+			c = &StockCover{NmID: nmID, WarehouseName: warehouse}
+			byKey[key] = c
+		}
+		return c
+	}
+
+	// остатки приходят по баркодам — складываются в товар на складе
+	for _, s := range stocks {
+		c := get(s.Int64("nmId"), s.String("warehouseName"))
+		c.Quantity += int(s.Float("quantity"))
+		c.InWayToClient += int(s.Float("inWayToClient"))
+		c.InWayFromClient += int(s.Float("inWayFromClient"))
+		if c.SupplierArticle == "" {
+			c.SupplierArticle = s.String("supplierArticle")
+		}
+	}
+	for _, o := range orders {
+		if o.Bool("isCancel") || o.String("date") < from {
+			continue
+		}
+		c := get(o.Int64("nmId"), o.String("warehouseName"))
+		c.Orders++
+		if c.SupplierArticle == "" {
+			c.SupplierArticle = o.String("supplierArticle")
+		}
+	}
+
+	out := make([]StockCover, 0, len(byKey))
+	for _, c := range byKey {
+		if c.Quantity <= 0 && c.Orders == 0 {
+			continue
+		}
+		c.OrdersPerDay = round2(float64(c.Orders) / float64(windowDays))
+		c.Status = CoverOK
+		if c.Orders > 0 {
+			days := math.Max(0, float64(c.Quantity)/(float64(c.Orders)/float64(windowDays)))
+			days = math.Round(days*10) / 10
+			c.DaysOfCover = &days
+			c.StockOutDate = now.AddDate(0, 0, int(math.Floor(days))).Format("2006-01-02")
+			switch {
+			case c.Quantity <= 0:
+				c.Status = CoverOut
+			case days < thresholdDays:
+				c.Status = CoverLow
+			}
+		}
+		out = append(out, *c)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		di, dj := math.Inf(1), math.Inf(1)
+		if out[i].DaysOfCover != nil {
+			di = *out[i].DaysOfCover
+		}
+		if out[j].DaysOfCover != nil {
+			dj = *out[j].DaysOfCover
+		}
+		if di != dj {
+			return di < dj
+		}
+		return out[i].Key() < out[j].Key()
+	})
+	return out
+}
@@ -0,0 +1,136 @@
+package analytics
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"wildberriesapi/internal/api"
+)
+
+// Виды тарифов в снимке
+const (
+	TariffCommission = "commission"
+	TariffBox        = "box"
+	TariffPallet     = "pallet"
+)
+
+// TariffSnapshot — снимок тарифов за день: "вид|категория или склад|поле" → значение в виде WB
+type TariffSnapshot struct {
+	Date   string            `json:"date"`
+	Values map[string]string `json:"values"`
+}
+
+// TariffChange — изменение одного тарифа между снимками
+type TariffChange struct {
+	Kind      string   `json:"kind"`   // commission, box, pallet
+	Entity    string   `json:"entity"` // предмет (commission) или склад (box, pallet)
+	Field     string   `json:"field"`
+	Action    string   `json:"action"` // changed, added, removed
+	Old       string   `json:"old,omitempty"`
+	New       string   `json:"new,omitempty"`
+	ChangePct *float64 `json:"change_pct,omitempty"` // только если оба значения числовые и старое не 0
+	PrevDate  string   `json:"prev_date"`
+	Date      string   `json:"date"`
+}
+
+// NewTariffSnapshot собирает снимок из комиссий, коробных и паллетных тарифов
+func NewTariffSnapshot(date string, commission []api.TariffItem, box []api.TariffsBox, pallet []api.TariffsPallet) TariffSnapshot {
+	s := TariffSnapshot{Date: date, Values: map[string]string{}}
+
+	for _, t := range commission {
+		entity := t.SubjectName + " (" + strconv.Itoa(t.SubjectID) + ")"
+		s.add(TariffCommission, entity, map[string]float64{
+			"kgvpMarketplace":     t.KgvpMarketplace,
+			"kgvpSupplier":        t.KgvpSupplier,
+			"kgvpSupplierExpress": t.KgvpSupplierExpress,
+			"kgvpPickup":          t.KgvpPickup,
+			"kgvpBooking":         t.KgvpBooking,
+			"paidStorageKgvp":     t.PaidStorageKgvp,
+		})
+	}
+	for _, b := range box {
+		for _, wh := range b.Data.WarehouseList {
+			s.addFields(TariffBox, wh.WarehouseName, wh)
+		}
+	}
+	for _, p := range pallet {
+		for _, wh := range p.Data.WarehouseList {
+			s.addFields(TariffPallet, wh.WarehouseName, wh)
+		}
+	}
+	return s
+}
+
+func (s TariffSnapshot) add(kind, entity string, fields map[string]float64) {
+	for f, v := range fields {
+		s.Values[kind+"|"+entity+"|"+f] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
+
+// addFields добавляет строковые поля тарифа склада в виде WB (кроме названия склада и региона);
+// разобранные числовые поля повторяют строковые и в снимок не попадают
+func (s TariffSnapshot) addFields(kind, entity string, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return
+	}
+	for f, val := range fields {
+		str, ok := val.(string)
+		if !ok || f == "warehouseName" || f == "geoName" {
+			continue
+		}
+		s.Values[kind+"|"+entity+"|"+f] = str
+	}
+}
+
+// DiffTariffs сравнивает два снимка и возвращает изменения, отсортированные по виду, сущности и полю
+func DiffTariffs(prev, cur TariffSnapshot) []TariffChange {
+	var out []TariffChange
+
+	change := func(key, action, old, new string) {
+		parts := strings.SplitN(key, "|", 3)
+		if len(parts) != 3 {
+			return
+		}
+		c := TariffChange{Kind: parts[0], Entity: parts[1], Field: parts[2], Action: action, Old: old, New: new, PrevDate: prev.Date, Date: cur.Date}
+		if o, ok := api.ParseWBNumber(old); ok && o != 0 {
+			if n, ok := api.ParseWBNumber(new); ok {
+				pct := round2((n - o) / o * 100)
+				c.ChangePct = &pct
+			}
+		}
+		out = append(out, c)
+	}
+
+	for key, v := range cur.Values {
+		old, ok := prev.Values[key]
+		switch {
+		case !ok:
+			change(key, "added", "", v)
+		case old != v:
+			change(key, "changed", old, v)
+		}
+	}
+	for key, old := range prev.Values {
+		if _, ok := cur.Values[key]; !ok {
+			change(key, "removed", old, "")
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		if out[i].Entity != out[j].Entity {
+			return out[i].Entity < out[j].Entity
+		}
+		return out[i].Field < out[j].Field
+	})
+	return out
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import "testing"
+
+func TestDiffTariffs(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+
+	prev := TariffSnapshot{Date: "2026-10-18", Values: map[string]string{
+		"box|Коледино|boxDeliveryBase":            "48",
+		"box|Коледино|boxDeliveryLiter":           "11,2",
+		"box|Коледино|boxStorageBase":             "0",
+		"box|Казань|boxDeliveryBase":              "-",
+		"box|Тула|boxDeliveryBase":                "40",
+		"commission|Платья|kgvpMarketplace":       "25",
+		"pallet|Коледино|palletDeliveryValueBase": "500",
+	}}
+	cur := TariffSnapshot{Date: "2026-10-19", Values: map[string]string{
+		"box|Коледино|boxDeliveryBase":      "60",
+		"box|Коледино|boxDeliveryLiter":     "11,2",
+		"box|Коледино|boxStorageBase":       "0,1",
+		"box|Казань|boxDeliveryBase":        "45",
+		"box|Тула|boxDeliveryBase":          "-",
+		"commission|Платья|kgvpMarketplace": "24,5",
+		"commission|Юбки|kgvpMarketplace":   "20",
+	}}
+
+	tests := []struct {
+		kind, entity, field string
+		action              string
+		old, new            string
+		pct                 *float64
+	}{
+		{"box", "Казань", "boxDeliveryBase", "changed", "-", "45", nil},
+		{"box", "Коледино", "boxDeliveryBase", "changed", "48", "60", pct(25)},
+		// старое значение 0 — процент не считается
+		{"box", "Коледино", "boxStorageBase", "changed", "0", "0,1", nil},
+		{"box", "Тула", "boxDeliveryBase", "changed", "40", "-", nil},
+		{"commission", "Платья", "kgvpMarketplace", "changed", "25", "24,5", pct(-2)},
+		{"commission", "Юбки", "kgvpMarketplace", "added", "", "20", nil},
+		{"pallet", "Коледино", "palletDeliveryValueBase", "removed", "500", "", nil},
+	}
+
+	got := DiffTariffs(prev, cur)
+	if len(got) != len(tests) {
+		t.Fatalf("got %d changes %+v, want %d", len(got), got, len(tests))
+	}
+	for i, tt := range tests {
+		c := got[i]
+		if c.Kind != tt.kind || c.Entity != tt.entity || c.Field != tt.field {
+			t.Errorf("change %d is %s|%s|%s, want %s|%s|%s", i, c.Kind, c.Entity, c.Field, tt.kind, tt.entity, tt.field)
+			continue
+		}
+		if c.Action != tt.action || c.Old != tt.old || c.New != tt.new {
+			t.Errorf("%s|%s|%s: %s %q → %q, want %s %q → %q", c.Kind, c.Entity, c.Field, c.Action, c.Old, c.New, tt.action, tt.old, tt.new)
+		}
+		switch {
+		case tt.pct == nil && c.ChangePct != nil:
+			t.Errorf("%s|%s|%s: change pct %v, want none", c.Kind, c.Entity, c.Field, *c.ChangePct)
+		case tt.pct != nil && (c.ChangePct == nil || *c.ChangePct != *tt.pct):
+			t.Errorf("%s|%s|%s: change pct %v, want %v", c.Kind, c.Entity, c.Field, c.ChangePct, *tt.pct)
+		}
+		if c.PrevDate != prev.Date || c.Date != cur.Date {
+			t.Errorf("%s|%s|%s: dates %s..%s, want %s..%s", c.Kind, c.Entity, c.Field, c.PrevDate, c.Date, prev.Date, cur.Date)
+		}
+	}
+
+	if diff := DiffTariffs(cur, cur); len(diff) != 0 {
+		t.Errorf("identical snapshots produced %d changes: %+v", len(diff), diff)
+	}
+}
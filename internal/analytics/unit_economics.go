@@ -0,0 +1,135 @@
+package analytics
+
+// DefaultReturnLogistics — обратная логистика невыкупленного товара, ₽ за единицу
+const DefaultReturnLogistics = 50
+
+// UnitInput — исходные данные артикула за период для расчёта юнит-экономики.
+// Поля *float64 — nil, если источник не дал значения; такие статьи попадают в Missing.
+type UnitInput struct {
+	NmID        int64
+	VendorCode  string
+	Title       string
+	SubjectName string
+	TokenIdx    int
+
+	Price       float64  // цена до скидки, ₽
+	DiscountPct float64  // скидка продавца, %
+	Commission  *float64 // комиссия WB по предмету, %
+	Delivery    *float64 // логистика одной единицы до покупателя, ₽
+	ReturnCost  float64  // обратная логистика невыкупа, ₽
+	Storage     *float64 // платное хранение артикула за период, ₽
+	AdSpend     *float64 // расходы на рекламу артикула за период, ₽
+	CostPrice   *float64 // себестоимость единицы, ₽
+
+	Orders  int // заказов за период
+	Buyouts int // выкупов за период
+}
+
+// UnitEconomics — юнит-экономика артикула в расчёте на один выкупленный товар, ₽
+type UnitEconomics struct {
+	NmID        int64   `json:"nm_id"`
+	VendorCode  string  `json:"vendor_code"`
+	Title       string  `json:"title,omitempty"`
+	SubjectName string  `json:"subject_name,omitempty"`
+	TokenIdx    int     `json:"token_idx"`
+	DateFrom    string  `json:"date_from"`
+	DateTo      string  `json:"date_to"`
+	Price       float64 `json:"price"`
+	DiscountPct float64 `json:"discount_pct"`
+	SalePrice   float64 `json:"sale_price"` // цена продавца после скидки
+
+	CommissionPct float64 `json:"commission_pct"`
+	Commission    float64 `json:"commission"`
+	Logistics     float64 `json:"logistics"` // доставка с учётом невыкупов и обратной логистики
+	Storage       float64 `json:"storage"`
+	Advertising   float64 `json:"advertising"`
+	CostPrice     float64 `json:"cost_price"`
+
+	Orders    int     `json:"orders"`
+	Buyouts   int     `json:"buyouts"`
+	BuyoutPct float64 `json:"buyout_pct"`
+	AdSpend   float64 `json:"ad_spend"` // реклама за весь период
+
+	Profit    float64 `json:"profit"`
+	MarginPct float64 `json:"margin_pct"` // прибыль к цене продажи
+	ROIPct    float64 `json:"roi_pct"`    // прибыль к себестоимости
+	// Missing — статьи, по которым не нашлось данных и которые посчитаны как 0
+	Missing []string `json:"missing,omitempty"`
+}
+
+// CalcUnitEconomics считает прибыль с одного выкупа. Логистика за выкуп — доставка каждого заказа
+// плюс обратная логистика невыкупов, делённые на выкупы; хранение и реклама за период делятся на выкупы.
+// Без заказов за период выкуп считается 100%. Без выкупов расходы на рекламу и хранение на единицу
+// не распределяются и попадают в Missing.
+func CalcUnitEconomics(in UnitInput, dateFrom, dateTo string) UnitEconomics {
+	u := UnitEconomics{
+		NmID:        in.NmID,
+		VendorCode:  in.VendorCode,
+		Title:       in.Title,
+		SubjectName: in.SubjectName,
+		TokenIdx:    in.TokenIdx,
+		DateFrom:    dateFrom,
+		DateTo:      dateTo,
+		Price:       in.Price,
+		DiscountPct: in.DiscountPct,
+		SalePrice:   round2(in.Price * (1 - in.DiscountPct/100)),
+		Orders:      in.Orders,
+		Buyouts:     in.Buyouts,
+	}
+
+	buyoutRate := 1.0
+	if in.Orders > 0 {
+		buyoutRate = float64(in.Buyouts) / float64(in.Orders)
+	} else {
+		u.Missing = append(u.Missing, "buyout_rate")
+	}
+	u.BuyoutPct = round2(buyoutRate * 100)
+
+	if in.Commission != nil {
+		u.CommissionPct = *in.Commission
+		u.Commission = round2(u.SalePrice * *in.Commission / 100)
+	} else {
+		u.Missing = append(u.Missing, "commission")
+	}
+
+	switch {
+	case in.Delivery == nil:
+		u.Missing = append(u.Missing, "logistics")
+	case buyoutRate > 0:
+		u.Logistics = round2((*in.Delivery + in.ReturnCost*(1-buyoutRate)) / buyoutRate)
+	default:
+		// заказы есть, выкупов нет — прибыль с выкупа не определена
+		u.Missing = append(u.Missing, "buyouts")
+	}
+
+	switch {
+	case in.Storage == nil, in.Buyouts == 0 && *in.Storage > 0:
+		u.Missing = append(u.Missing, "storage")
+	case in.Buyouts > 0:
+		u.Storage = round2(*in.Storage / float64(in.Buyouts))
+	}
+	if in.AdSpend != nil {
+		u.AdSpend = round2(*in.AdSpend)
+	}
+	switch {
+	case in.AdSpend == nil, in.Buyouts == 0 && *in.AdSpend > 0:
+		u.Missing = append(u.Missing, "advertising")
+	case in.Buyouts > 0:
+		u.Advertising = round2(*in.AdSpend / float64(in.Buyouts))
+	}
+
+	if in.CostPrice != nil {
+		u.CostPrice = *in.CostPrice
+	} else {
+		u.Missing = append(u.Missing, "cost_price")
+	}
+
+	u.Profit = round2(u.SalePrice - u.Commission - u.Logistics - u.Storage - u.Advertising - u.CostPrice)
+	if u.SalePrice > 0 {
+		u.MarginPct = round2(u.Profit / u.SalePrice * 100)
+	}
+	if u.CostPrice > 0 {
+		u.ROIPct = round2(u.Profit / u.CostPrice * 100)
+	}
+	return u
+}
@@ -0,0 +1,143 @@
+package answers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	KindFeedback = "feedback"
+	KindQuestion = "question"
+)
+
+// ErrNoTemplate — для товара/оценки не нашлось подходящего шаблона
+var ErrNoTemplate = errors.New("no matching answer template")
+
+// Template — шаблон ответа. NmID=0 и Rating=0 означают «любой».
+type Template struct {
+	Kind   string `json:"kind"` // "feedback" (по умолчанию) или "question"
+	NmID   int64  `json:"nmId"`
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+
+	tpl *template.Template
+}
+
+// Data — поля, доступные в шаблоне: {{.UserName}}, {{.ProductName}}, {{.Brand}}, {{.Rating}}
+type Data struct {
+	UserName    string
+	ProductName string
+	Brand       string
+	Rating      int
+}
+
+// Templates — набор шаблонов ответов
+type Templates struct {
+	items []Template
+}
+
+// LoadTemplates читает шаблоны из JSON-файла (массив Template).
+// Пустой путь — пустой набор шаблонов.
+func LoadTemplates(path string) (*Templates, error) {
+	t := &Templates{}
+	if path == "" {
+		return t, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read answer templates: %w", err)
+	}
+
+	var items []Template
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("parse answer templates: %w", err)
+	}
+
+	for i := range items {
+		if items[i].Kind == "" {
+			items[i].Kind = KindFeedback
+		}
+		tpl, err := template.New(fmt.Sprintf("answer_%d", i)).Parse(items[i].Text)
+		if err != nil {
+			return nil, fmt.Errorf("template #%d: %w", i, err)
+		}
+		items[i].tpl = tpl
+	}
+	t.items = items
+	return t, nil
+}
+
+// Find подбирает шаблон: сначала nmId+rating, затем nmId, затем rating, затем общий
+func (t *Templates) Find(kind string, nmID int64, rating int) (*Template, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	var best *Template
+	bestScore := -1
+	for i := range t.items {
+		it := &t.items[i]
+		if it.Kind != kind {
+			continue
+		}
+		if it.NmID != 0 && it.NmID != nmID {
+			continue
+		}
+		if it.Rating != 0 && it.Rating != rating {
+			continue
+		}
+
+		score := 0
+		if it.NmID != 0 {
+			score += 2
+		}
+		if it.Rating != 0 {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = it, score
+		}
+	}
+	return best, best != nil
+}
+
+// Render подбирает шаблон и подставляет в него данные
+func (t *Templates) Render(kind string, nmID int64, rating int, data Data) (string, error) {
+	tpl, ok := t.Find(kind, nmID, rating)
+	if !ok {
+		return "", fmt.Errorf("%w: %s nmId=%d rating=%d", ErrNoTemplate, kind, nmID, rating)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// HasKind сообщает, есть ли хотя бы один шаблон данного вида
+func (t *Templates) HasKind(kind string) bool {
+	if t == nil {
+		return false
+	}
+	for i := range t.items {
+		if t.items[i].Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// List возвращает все загруженные шаблоны
+func (t *Templates) List() []Template {
+	if t == nil {
+		return nil
+	}
+	return t.items
+}
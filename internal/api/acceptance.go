@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Типы упаковки поставки FBW
+const (
+	BoxTypeBox        = 2 // короба
+	BoxTypeMonopallet = 5 // монопаллеты
+	BoxTypeSupersafe  = 6 // суперсейф
+)
+
+// AcceptanceCoefficient — коэффициент приёмки склада на дату для типа упаковки.
+// Coefficient: -1 — приёмка недоступна, 0 — бесплатно, N — платная приёмка с множителем N.
+type AcceptanceCoefficient struct {
+	Date                    string  `json:"date"`
+	Coefficient             float64 `json:"coefficient"`
+	WarehouseID             int64   `json:"warehouseID"`
+	WarehouseName           string  `json:"warehouseName"`
+	AllowUnload             bool    `json:"allowUnload"`
+	BoxTypeName             string  `json:"boxTypeName"`
+	BoxTypeID               int     `json:"boxTypeID"`
+	StorageCoef             string  `json:"storageCoef"`
+	DeliveryCoef            string  `json:"deliveryCoef"`
+	DeliveryBaseLiter       string  `json:"deliveryBaseLiter"`
+	DeliveryAdditionalLiter string  `json:"deliveryAdditionalLiter"`
+	StorageBaseLiter        string  `json:"storageBaseLiter"`
+	StorageAdditionalLiter  string  `json:"storageAdditionalLiter"`
+	IsSortingCenter         bool    `json:"isSortingCenter"`
+}
+
+// Available — можно ли везти поставку: приёмка открыта и склад принимает разгрузку
+func (a AcceptanceCoefficient) Available() bool {
+	return a.AllowUnload && a.Coefficient >= 0
+}
+
+// SupplyWarehouse — склад WB для поставок FBW
+type SupplyWarehouse struct {
+	ID              int64  `json:"ID"`
+	Name            string `json:"name"`
+	Address         string `json:"address"`
+	WorkTime        string `json:"workTime"`
+	AcceptsQR       bool   `json:"acceptsQR"`
+	IsActive        bool   `json:"isActive"`
+	IsTransitActive bool   `json:"isTransitActive"`
+}
+
+// ParseWBNumber разбирает числа, которые WB отдаёт строками ("48", "11,2", "-"); ошибка — 0, false
+func ParseWBNumber(s string) (float64, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// GetAcceptanceCoefficients возвращает коэффициенты приёмки на ближайшие 14 дней (пусто warehouseIDs — все склады)
+func (c *WBClient) GetAcceptanceCoefficients(ctx context.Context, tokenIdx int, warehouseIDs []int64) ([]AcceptanceCoefficient, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	u := WBEndpoints.AcceptanceCoefficients.URL
+	if len(warehouseIDs) > 0 {
+		ids := make([]string, 0, len(warehouseIDs))
+		for _, id := range warehouseIDs {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		u += "?" + url.Values{"warehouseIDs": {strings.Join(ids, ",")}}.Encode()
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, u, token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("acceptance coefficients: %w", err)
+	}
+
+	var out []AcceptanceCoefficient
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal acceptance coefficients: %w", err)
+	}
+	return out, nil
+}
+
+// GetSupplyWarehouses возвращает склады WB, принимающие поставки
+func (c *WBClient) GetSupplyWarehouses(ctx context.Context, tokenIdx int) ([]SupplyWarehouse, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.SupplyWarehouses.URL, token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("supply warehouses: %w", err)
+	}
+
+	var out []SupplyWarehouse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal supply warehouses: %w", err)
+	}
+	return out, nil
+}
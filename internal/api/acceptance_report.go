@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+)
+
+// AcceptanceReportRow — строка отчёта о платной приёмке
+type AcceptanceReportRow struct {
+	Count         int     `json:"count"`         // количество товаров, шт.
+	GiCreateDate  string  `json:"giCreateDate"`  // дата создания поставки
+	IncomeID      int64   `json:"incomeId"`      // номер поставки
+	NmID          int64   `json:"nmID"`          // артикул WB
+	ShkCreateDate string  `json:"shkCreateDate"` // дата приёмки
+	SubjectName   string  `json:"subjectName"`   // предмет
+	Total         float64 `json:"total"`         // суммарная стоимость приёмки, ₽
+}
+
+// StartAcceptanceReport создаёт задачи на отчёт о платной приёмке по всем токенам (период — до 31 дня)
+func (c *WBClient) StartAcceptanceReport(ctx context.Context, dateFrom, dateTo string) []ReportTask {
+	return c.StartReportTasks(ctx, AcceptanceReport, periodQuery(dateFrom, dateTo))
+}
+
+// StartAcceptanceReportTask создаёт задачу на отчёт о платной приёмке для одного токена
+func (c *WBClient) StartAcceptanceReportTask(ctx context.Context, tokenIdx int, dateFrom, dateTo string) (string, error) {
+	return c.StartReportTask(ctx, tokenIdx, AcceptanceReport, periodQuery(dateFrom, dateTo))
+}
+
+// GetAcceptanceReportStatus проверяет статус задачи по token_idx и task_id
+func (c *WBClient) GetAcceptanceReportStatus(ctx context.Context, tokenIdx int, taskID string) (*ReportTaskStatus, error) {
+	return c.GetReportTaskStatus(ctx, tokenIdx, AcceptanceReport, taskID)
+}
+
+// GetAcceptanceReportDownload скачивает отчёт о платной приёмке по token_idx и task_id
+func (c *WBClient) GetAcceptanceReportDownload(ctx context.Context, tokenIdx int, taskID string) ([]AcceptanceReportRow, error) {
+	var rows []AcceptanceReportRow
+	if err := c.DownloadReportTask(ctx, tokenIdx, AcceptanceReport, taskID, &rows); err != nil {
+		return nil, err
+	}
+
+	c.Logger.Info().Msgf("✅ Acceptance report downloaded (token_%d, records=%d)", tokenIdx, len(rows))
+	return rows, nil
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// advertKeywordsMaxPeriod — максимальный период одного запроса статистики по ключевым фразам
+const advertKeywordsMaxPeriod = 7 * 24 * time.Hour
+
+// Типы рекламных кампаний
+const (
+	AdvertTypeSearch  = 6 // поиск
+	AdvertTypeAuto    = 8 // автоматическая
+	AdvertTypeAuction = 9 // аукцион (поиск + каталог)
+)
+
+// AdvertKeywordStat — статистика ключевой фразы кампании за день
+type AdvertKeywordStat struct {
+	AdvertID int64   `json:"advertId"`
+	Date     string  `json:"date"`
+	Keyword  string  `json:"keyword"`
+	Views    int64   `json:"views"`
+	Clicks   int64   `json:"clicks"`
+	CTR      float64 `json:"ctr"`
+	Sum      float64 `json:"sum"`
+	TokenIdx int     `json:"token_idx"`
+}
+
+// AdvertCluster — кластер ключевых фраз автоматической кампании
+type AdvertCluster struct {
+	AdvertID int64    `json:"advertId"`
+	Cluster  string   `json:"cluster"`
+	Count    int64    `json:"count"` // показы
+	Keywords []string `json:"keywords"`
+	Excluded bool     `json:"excluded"`
+	TokenIdx int      `json:"token_idx"`
+}
+
+// AdvertWordStat — статистика фразы поисковой кампании за период кампании
+type AdvertWordStat struct {
+	Keyword  string  `json:"keyword"`
+	Begin    string  `json:"begin"`
+	End      string  `json:"end"`
+	Views    int64   `json:"views"`
+	Clicks   int64   `json:"clicks"`
+	Frq      float64 `json:"frq"`
+	CTR      float64 `json:"ctr"`
+	CPC      float64 `json:"cpc"`
+	Duration int64   `json:"duration"`
+	Sum      float64 `json:"sum"`
+}
+
+// AdvertSearchWords — фразы и статистика поисковой кампании
+type AdvertSearchWords struct {
+	AdvertID int64 `json:"advertId"`
+	Words    struct {
+		Phrase   []string `json:"phrase"`
+		Strong   []string `json:"strong"`
+		Excluded []string `json:"excluded"`
+		Pluse    []string `json:"pluse"`
+		Keywords []struct {
+			Keyword string `json:"keyword"`
+			Count   int64  `json:"count"`
+		} `json:"keywords"`
+		Fixed bool `json:"fixed"`
+	} `json:"words"`
+	Stat     []AdvertWordStat `json:"stat"`
+	TokenIdx int              `json:"token_idx"`
+}
+
+// GetAdvertKeywordStats получает статистику по ключевым фразам кампании по дням.
+// Период больше 7 дней разбивается на несколько запросов.
+func (c *WBClient) GetAdvertKeywordStats(ctx context.Context, tokenIdx int, advertID int64, from, to time.Time) ([]AdvertKeywordStat, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]AdvertKeywordStat, 0)
+	for start := from; !start.After(to); start = start.Add(advertKeywordsMaxPeriod) {
+		end := start.Add(advertKeywordsMaxPeriod - 24*time.Hour)
+		if end.After(to) {
+			end = to
+		}
+
+		q := url.Values{}
+		q.Set("advert_id", strconv.FormatInt(advertID, 10))
+		q.Set("from", start.Format("2006-01-02"))
+		q.Set("to", end.Format("2006-01-02"))
+
+		body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.AdvertKeywordsStat.URL+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Keywords []struct {
+				Date  string `json:"date"`
+				Stats []struct {
+					Keyword string  `json:"keyword"`
+					Views   int64   `json:"views"`
+					Clicks  int64   `json:"clicks"`
+					CTR     float64 `json:"ctr"`
+					Sum     float64 `json:"sum"`
+				} `json:"stats"`
+			} `json:"keywords"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal advert keyword stats: %w", err)
+		}
+
+		for _, day := range resp.Keywords {
+			for _, s := range day.Stats {
+				all = append(all, AdvertKeywordStat{
+					AdvertID: advertID,
+					Date:     day.Date,
+					Keyword:  s.Keyword,
+					Views:    s.Views,
+					Clicks:   s.Clicks,
+					CTR:      s.CTR,
+					Sum:      s.Sum,
+					TokenIdx: tokenIdx,
+				})
+			}
+		}
+
+		// лимит — 4 запроса в секунду
+		time.Sleep(250 * time.Millisecond)
+	}
+	return all, nil
+}
+
+// GetAdvertAutoClusters получает кластеры фраз автоматической кампании (включая исключённые)
+func (c *WBClient) GetAdvertAutoClusters(ctx context.Context, tokenIdx int, advertID int64) ([]AdvertCluster, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := WBEndpoints.AdvertAutoStat.URL + "?id=" + strconv.FormatInt(advertID, 10)
+	body, err := c.doRequest(ctx, http.MethodGet, reqURL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Clusters []AdvertCluster `json:"clusters"`
+		Excluded []string        `json:"excluded"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal advert clusters: %w", err)
+	}
+
+	out := make([]AdvertCluster, 0, len(resp.Clusters)+len(resp.Excluded))
+	for _, cl := range resp.Clusters {
+		cl.AdvertID, cl.TokenIdx = advertID, tokenIdx
+		out = append(out, cl)
+	}
+	for _, phrase := range resp.Excluded {
+		out = append(out, AdvertCluster{AdvertID: advertID, Cluster: phrase, Excluded: true, TokenIdx: tokenIdx})
+	}
+	return out, nil
+}
+
+// GetAdvertSearchWords получает фразы и статистику по фразам поисковой кампании
+func (c *WBClient) GetAdvertSearchWords(ctx context.Context, tokenIdx int, advertID int64) (*AdvertSearchWords, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := WBEndpoints.AdvertStatWords.URL + "?id=" + strconv.FormatInt(advertID, 10)
+	body, err := c.doRequest(ctx, http.MethodGet, reqURL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var words AdvertSearchWords
+	if err := json.Unmarshal(body, &words); err != nil {
+		return nil, fmt.Errorf("unmarshal advert search words: %w", err)
+	}
+	words.AdvertID, words.TokenIdx = advertID, tokenIdx
+	return &words, nil
+}
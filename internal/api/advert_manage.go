@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Источники пополнения бюджета кампании
+const (
+	AdvertDepositAccount = 0 // счёт
+	AdvertDepositBalance = 1 // баланс
+	AdvertDepositBonus   = 3 // бонусы
+)
+
+// AdvertBudget — бюджет кампании
+type AdvertBudget struct {
+	Cash    float64 `json:"cash"`
+	Netting float64 `json:"netting"`
+	Total   float64 `json:"total"`
+}
+
+// AdvertNmBid — ставка (CPM, ₽) для товара в кампании
+type AdvertNmBid struct {
+	Nm  int64 `json:"nm"`
+	Bid int   `json:"bid"`
+}
+
+// AdvertBid — ставки по товарам одной кампании
+type AdvertBid struct {
+	AdvertID int64         `json:"advert_id"`
+	NmBids   []AdvertNmBid `json:"nm_bids"`
+}
+
+func advertIDQuery(advertID int64) string {
+	return "?id=" + strconv.FormatInt(advertID, 10)
+}
+
+// StartAdvert запускает кампанию (из статуса «готова к запуску» или «пауза»)
+func (c *WBClient) StartAdvert(ctx context.Context, tokenIdx int, advertID int64) error {
+	return c.advertCommand(ctx, tokenIdx, WBEndpoints.AdvertStart.URL, advertID, "start")
+}
+
+// PauseAdvert ставит кампанию на паузу
+func (c *WBClient) PauseAdvert(ctx context.Context, tokenIdx int, advertID int64) error {
+	return c.advertCommand(ctx, tokenIdx, WBEndpoints.AdvertPause.URL, advertID, "pause")
+}
+
+// StopAdvert завершает кампанию (необратимо)
+func (c *WBClient) StopAdvert(ctx context.Context, tokenIdx int, advertID int64) error {
+	return c.advertCommand(ctx, tokenIdx, WBEndpoints.AdvertStop.URL, advertID, "stop")
+}
+
+func (c *WBClient) advertCommand(ctx context.Context, tokenIdx int, endpoint string, advertID int64, action string) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.doWrite(ctx, http.MethodGet, endpoint+advertIDQuery(advertID), token, nil); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to %s advert %d", action, advertID)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ advert %d: %s", advertID, action)
+	return nil
+}
+
+// GetAdvertBudget получает бюджет кампании
+func (c *WBClient) GetAdvertBudget(ctx context.Context, tokenIdx int, advertID int64) (*AdvertBudget, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.AdvertBudget.URL+advertIDQuery(advertID), token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var budget AdvertBudget
+	if err := json.Unmarshal(body, &budget); err != nil {
+		return nil, fmt.Errorf("unmarshal advert budget: %w", err)
+	}
+	return &budget, nil
+}
+
+// DepositAdvertBudget пополняет бюджет кампании и возвращает новый размер бюджета.
+// source — AdvertDepositAccount, AdvertDepositBalance или AdvertDepositBonus.
+func (c *WBClient) DepositAdvertBudget(ctx context.Context, tokenIdx int, advertID int64, sum, source int) (float64, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return 0, err
+	}
+
+	payload := map[string]any{"sum": sum, "type": source, "return": true}
+	body, err := c.doWrite(ctx, http.MethodPost, WBEndpoints.AdvertBudgetDeposit.URL+advertIDQuery(advertID), token, payload)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to deposit %d to advert %d", sum, advertID)
+		return 0, err
+	}
+
+	var resp struct {
+		Total float64 `json:"total"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		// WB принял пополнение, но новый бюджет неизвестен
+		return 0, fmt.Errorf("%w: deposit accepted, unmarshal advert deposit: %v", ErrOutcomeUnknown, err)
+	}
+	c.Logger.Info().Msgf("✅ advert %d budget topped up by %d (total %.0f)", advertID, sum, resp.Total)
+	return resp.Total, nil
+}
+
+// SetAdvertBids меняет ставки CPM по товарам кампаний
+func (c *WBClient) SetAdvertBids(ctx context.Context, tokenIdx int, bids []AdvertBid) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.doWrite(ctx, http.MethodPatch, WBEndpoints.AdvertBids.URL, token, map[string]any{"bids": bids}); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to set bids for %d adverts", len(bids))
+		return err
+	}
+	c.Logger.Info().Msgf("✅ bids updated for %d adverts", len(bids))
+	return nil
+}
+
+// SetAdvertExcluded заменяет список минус-фраз кампании.
+// Для поисковых кампаний и автоматических/аукционных используются разные методы WB.
+func (c *WBClient) SetAdvertExcluded(ctx context.Context, tokenIdx int, advertID int64, advertType int, phrases []string) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := WBEndpoints.AdvertAutoExcluded.URL
+	if advertType == AdvertTypeSearch {
+		endpoint = WBEndpoints.AdvertSearchExcluded.URL
+	}
+	if phrases == nil {
+		phrases = []string{}
+	}
+
+	if _, err := c.doWrite(ctx, http.MethodPost, endpoint+advertIDQuery(advertID), token, map[string]any{"excluded": phrases}); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to set minus phrases for advert %d", advertID)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ advert %d: %d minus phrases set", advertID, len(phrases))
+	return nil
+}
@@ -3,48 +3,295 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"time"
 )
 
+const (
+	// advertDetailsBatch — максимум ID кампаний в запросе информации о кампаниях
+	advertDetailsBatch = 50
+	// advertStatsBatch — максимум ID кампаний в запросе статистики
+	advertStatsBatch = 100
+	// advertStatsPause — лимит fullstats: 1 запрос в минуту
+	advertStatsPause = time.Minute
+)
+
+// Статусы рекламных кампаний
+const (
+	AdvertStatusDeleted   = -1
+	AdvertStatusReady     = 4  // готова к запуску
+	AdvertStatusCompleted = 7  // завершена
+	AdvertStatusDeclined  = 8  // отказ
+	AdvertStatusActive    = 9  // идут показы
+	AdvertStatusPaused    = 11 // на паузе
+)
+
+// AdvertRef — кампания из списка /promotion/count
+type AdvertRef struct {
+	AdvertID   int64     `json:"advertId"`
+	Type       int       `json:"type"`
+	Status     int       `json:"status"`
+	ChangeTime time.Time `json:"changeTime"`
+}
+
 // AdvertCampaign — структура для описания рекламной кампании WB
 type AdvertCampaign struct {
-	CampaignID   int64   `json:"advertId"`
-	CampaignName string  `json:"name"`
-	Status       string  `json:"status"`
-	Budget       float64 `json:"dailyBudget"`
-	Type         string  `json:"type"`
-	SupplierID   int     `json:"__supplier_id"`
+	AdvertID    int64     `json:"advertId"`
+	Name        string    `json:"name"`
+	Type        int       `json:"type"`
+	Status      int       `json:"status"`
+	DailyBudget float64   `json:"dailyBudget"`
+	PaymentType string    `json:"paymentType"`
+	CreateTime  time.Time `json:"createTime"`
+	ChangeTime  time.Time `json:"changeTime"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	TokenIdx    int       `json:"token_idx"`
 }
 
-// GetAdverts получает список всех рекламных кампаний по токенам
-func (c *WBClient) GetAdverts(ctx context.Context) ([]AdvertCampaign, error) {
-	allCampaigns := make([]AdvertCampaign, 0)
+// AdvertMetrics — показатели кампании (за период, день или по товару)
+type AdvertMetrics struct {
+	Views    int64   `json:"views"`
+	Clicks   int64   `json:"clicks"`
+	CTR      float64 `json:"ctr"`
+	CPC      float64 `json:"cpc"`
+	Sum      float64 `json:"sum"` // затраты, ₽
+	Atbs     int64   `json:"atbs"`
+	Orders   int64   `json:"orders"`
+	CR       float64 `json:"cr"`
+	Shks     int64   `json:"shks"`
+	SumPrice float64 `json:"sum_price"`
+}
 
-	for _, token := range c.Tokens {
-		if token == "" {
+// AdvertNmStat — статистика товара в кампании за день
+type AdvertNmStat struct {
+	AdvertMetrics
+	NmID int64  `json:"nmId"`
+	Name string `json:"name"`
+}
+
+// AdvertAppStat — статистика по платформе (сайт, Android, iOS) за день
+type AdvertAppStat struct {
+	AdvertMetrics
+	AppType int            `json:"appType"`
+	Nm      []AdvertNmStat `json:"nm"`
+}
+
+// AdvertDayStat — статистика кампании за день
+type AdvertDayStat struct {
+	AdvertMetrics
+	Date time.Time       `json:"date"`
+	Apps []AdvertAppStat `json:"apps"`
+}
+
+// AdvertFullStats — полная статистика кампании за период
+type AdvertFullStats struct {
+	AdvertMetrics
+	AdvertID int64           `json:"advertId"`
+	Days     []AdvertDayStat `json:"days"`
+	TokenIdx int             `json:"token_idx"`
+}
+
+// AdvertNmDayStat — плоская строка статистики: кампания × день × товар
+type AdvertNmDayStat struct {
+	AdvertMetrics
+	AdvertID int64  `json:"advertId"`
+	Date     string `json:"date"`
+	NmID     int64  `json:"nmId"`
+	Name     string `json:"name"`
+	TokenIdx int    `json:"token_idx"`
+}
+
+// ByNmDay сворачивает статистику платформ в строки по дню и товару
+func (s AdvertFullStats) ByNmDay() []AdvertNmDayStat {
+	out := make([]AdvertNmDayStat, 0)
+	for _, d := range s.Days {
+		date := d.Date.Format("2006-01-02")
+		byNm := map[int64]*AdvertNmDayStat{}
+		order := make([]int64, 0)
+		for _, app := range d.Apps {
+			for _, nm := range app.Nm {
+				row, ok := byNm[nm.NmID]
+				if !ok {
+					row = &AdvertNmDayStat{AdvertID: s.AdvertID, Date: date, NmID: nm.NmID, Name: nm.Name, TokenIdx: s.TokenIdx}
+					byNm[nm.NmID] = row
+					order = append(order, nm.NmID)
+				}
+				row.Views += nm.Views
+				row.Clicks += nm.Clicks
+				row.Sum += nm.Sum
+				row.Atbs += nm.Atbs
+				row.Orders += nm.Orders
+				row.Shks += nm.Shks
+				row.SumPrice += nm.SumPrice
+			}
+		}
+		for _, id := range order {
+			row := byNm[id]
+			// CTR, CPC и CR пересчитываются по суммам, а не усредняются
+			if row.Views > 0 {
+				row.CTR = float64(row.Clicks) / float64(row.Views) * 100
+			}
+			if row.Clicks > 0 {
+				row.CPC = row.Sum / float64(row.Clicks)
+				row.CR = float64(row.Orders) / float64(row.Clicks) * 100
+			}
+			out = append(out, *row)
+		}
+	}
+	return out
+}
+
+// GetAdvertIDs получает список кампаний токена; statuses — фильтр по статусам (пусто — все)
+func (c *WBClient) GetAdvertIDs(ctx context.Context, tokenIdx int, statuses ...int) ([]AdvertRef, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.AdvertCount.URL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Adverts []struct {
+			Type       int         `json:"type"`
+			Status     int         `json:"status"`
+			AdvertList []AdvertRef `json:"advert_list"`
+		} `json:"adverts"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal advert count: %w", err)
+	}
+
+	want := make(map[int]bool, len(statuses))
+	for _, s := range statuses {
+		want[s] = true
+	}
+
+	refs := make([]AdvertRef, 0)
+	for _, group := range resp.Adverts {
+		if len(want) > 0 && !want[group.Status] {
 			continue
 		}
+		for _, ref := range group.AdvertList {
+			ref.Type, ref.Status = group.Type, group.Status
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// GetAdvertDetails получает информацию о кампаниях пачками по 50 ID
+func (c *WBClient) GetAdvertDetails(ctx context.Context, tokenIdx int, ids []int64) ([]AdvertCampaign, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]AdvertCampaign, 0, len(ids))
+	for _, batch := range chunkInt64Slice(ids, advertDetailsBatch) {
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.AdvertCampaigns.URL, token, batch)
+		if err != nil {
+			return all, err
+		}
+
+		var campaigns []AdvertCampaign
+		if err := json.Unmarshal(body, &campaigns); err != nil {
+			return all, fmt.Errorf("unmarshal advert details: %w", err)
+		}
+		for i := range campaigns {
+			campaigns[i].TokenIdx = tokenIdx
+		}
+		all = append(all, campaigns...)
 
-		url := "https://advert-api.wildberries.ru/adv/v1/promotion/count"
+		time.Sleep(250 * time.Millisecond)
+	}
+	return all, nil
+}
+
+// GetAdvertFullStats получает полную статистику кампаний за период пачками по 100 ID.
+// Лимит метода — 1 запрос в минуту, поэтому между пачками выдерживается пауза.
+func (c *WBClient) GetAdvertFullStats(ctx context.Context, tokenIdx int, ids []int64, begin, end time.Time) ([]AdvertFullStats, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	type interval struct {
+		Begin string `json:"begin"`
+		End   string `json:"end"`
+	}
+	type statsRequest struct {
+		ID       int64    `json:"id"`
+		Interval interval `json:"interval"`
+	}
+	period := interval{Begin: begin.Format("2006-01-02"), End: end.Format("2006-01-02")}
+
+	all := make([]AdvertFullStats, 0, len(ids))
+	for i, batch := range chunkInt64Slice(ids, advertStatsBatch) {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return all, ctx.Err()
+			case <-time.After(advertStatsPause):
+			}
+		}
 
-		body, err := c.doRequest(ctx, "GET", url, token, nil)
+		payload := make([]statsRequest, 0, len(batch))
+		for _, id := range batch {
+			payload = append(payload, statsRequest{ID: id, Interval: period})
+		}
+
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.AdvertFullStats.URL, token, payload)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("❌ failed to fetch adverts ")
+			return all, err
+		}
+		// по кампаниям без статистики за период WB возвращает null
+		if string(body) == "null" {
 			continue
 		}
 
-		var resp struct {
-			Data []AdvertCampaign `json:"adverts"`
+		var stats []AdvertFullStats
+		if err := json.Unmarshal(body, &stats); err != nil {
+			return all, fmt.Errorf("unmarshal advert fullstats: %w", err)
 		}
-		if err := json.Unmarshal(body, &resp); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal error in GetAdverts response")
+		for i := range stats {
+			stats[i].TokenIdx = tokenIdx
+		}
+		all = append(all, stats...)
+	}
+	return all, nil
+}
+
+// GetAdverts получает рекламные кампании в указанных статусах по всем токенам
+func (c *WBClient) GetAdverts(ctx context.Context, statuses ...int) ([]AdvertCampaign, error) {
+	allCampaigns := make([]AdvertCampaign, 0)
+
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+
+		refs, err := c.GetAdvertIDs(ctx, idx+1, statuses...)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to fetch adverts (token_%d)", idx+1)
 			continue
 		}
 
-		allCampaigns = append(allCampaigns, resp.Data...)
-		c.Logger.Info().Msgf("✅ supplier_id=%d: adverts loaded (%d records)", len(resp.Data))
+		ids := make([]int64, 0, len(refs))
+		for _, ref := range refs {
+			ids = append(ids, ref.AdvertID)
+		}
+		campaigns, err := c.GetAdvertDetails(ctx, idx+1, ids)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to fetch advert details (token_%d)", idx+1)
+		}
 
-		time.Sleep(700 * time.Millisecond)
+		allCampaigns = append(allCampaigns, campaigns...)
+		c.Logger.Info().Msgf("✅ adverts loaded (%d records, token_%d)", len(campaigns), idx+1)
 	}
 
 	return allCampaigns, nil
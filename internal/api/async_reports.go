@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Статусы асинхронных задач отчётов WB (платное хранение, остатки на складах, платная приёмка)
+const (
+	ReportTaskNew        = "new"
+	ReportTaskProcessing = "processing"
+	ReportTaskDone       = "done"
+	ReportTaskPurged     = "purged"   // отчёт удалён, задачу нужно создать заново
+	ReportTaskCanceled   = "canceled" // задача отклонена WB
+)
+
+// AsyncReport — отчёт WB со сценарием "создать задачу → проверить статус → скачать".
+// URL Status и Download содержат %s для task_id.
+type AsyncReport struct {
+	Name     string
+	Start    WBEndpoint
+	Status   WBEndpoint
+	Download WBEndpoint
+}
+
+var (
+	PaidStorageReport      = AsyncReport{"paid_storage", WBEndpoints.PaidStorageStart, WBEndpoints.PaidStorageStatus, WBEndpoints.PaidStorageDownload}
+	WarehouseRemainsReport = AsyncReport{"warehouse_remains", WBEndpoints.WarehouseRemainsStart, WBEndpoints.WarehouseRemainsStatus, WBEndpoints.WarehouseRemainsDownload}
+	AcceptanceReport       = AsyncReport{"acceptance_report", WBEndpoints.AcceptanceReportStart, WBEndpoints.AcceptanceReportStatus, WBEndpoints.AcceptanceReportDownload}
+)
+
+// ReportTask — задача асинхронного отчёта.
+// Задача существует только в кабинете продавца, который её создал, поэтому
+// статус и скачивание запрашиваются с тем же TokenIdx.
+type ReportTask struct {
+	TokenIdx int    `json:"token_idx"`
+	TaskID   string `json:"task_id"`
+}
+
+// ReportTaskStatus — ответ WB на проверку статуса задачи
+type ReportTaskStatus struct {
+	Data struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"data"`
+}
+
+// StartReportTask создаёт задачу отчёта для одного токена; q — параметры отчёта (период, группировки)
+func (c *WBClient) StartReportTask(ctx context.Context, tokenIdx int, report AsyncReport, q url.Values) (string, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return "", err
+	}
+
+	u := report.Start.URL
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, u, token, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Data struct {
+			TaskID string `json:"taskId"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("unmarshal %s start: %w", report.Name, err)
+	}
+	if resp.Data.TaskID == "" {
+		return "", fmt.Errorf("unexpected %s start response: %s", report.Name, string(body))
+	}
+
+	c.Logger.Info().Msgf("✅ %s started: token_%d, task_id=%s", report.Name, tokenIdx, resp.Data.TaskID)
+	return resp.Data.TaskID, nil
+}
+
+// StartReportTasks создаёт задачу отчёта по каждому токену; токены с ошибкой пропускаются
+func (c *WBClient) StartReportTasks(ctx context.Context, report AsyncReport, q url.Values) []ReportTask {
+	results := make([]ReportTask, 0)
+
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+
+		taskID, err := c.StartReportTask(ctx, idx+1, report, q)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ Failed to start %s (token_%d)", report.Name, idx+1)
+			continue
+		}
+
+		results = append(results, ReportTask{TokenIdx: idx + 1, TaskID: taskID})
+	}
+
+	return results
+}
+
+// GetReportTaskStatus проверяет статус задачи по token_idx и task_id
+func (c *WBClient) GetReportTaskStatus(ctx context.Context, tokenIdx int, report AsyncReport, taskID string) (*ReportTaskStatus, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf(report.Status.URL, taskID), token, nil)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ Failed to get %s status (token_%d, task_id=%s)", report.Name, tokenIdx, taskID)
+		return nil, err
+	}
+
+	var status ReportTaskStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("unmarshal %s status: %w", report.Name, err)
+	}
+
+	return &status, nil
+}
+
+// DownloadReportTask скачивает результат задачи и разбирает его в out (срез строк отчёта)
+func (c *WBClient) DownloadReportTask(ctx context.Context, tokenIdx int, report AsyncReport, taskID string, out any) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Info().Msgf("⬇️ Downloading %s report (token_%d, task_id=%s)", report.Name, tokenIdx, taskID)
+
+	// увеличенный таймаут, потому что ответ может быть большим
+	localCtx, cancel := context.WithTimeout(ctx, 4*time.Minute)
+	defer cancel()
+
+	body, err := c.doRequest(localCtx, http.MethodGet, fmt.Sprintf(report.Download.URL, taskID), token, nil)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ Download error (%s, token_%d, task_id=%s)", report.Name, tokenIdx, taskID)
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse %s download: %w", report.Name, err)
+	}
+	return nil
+}
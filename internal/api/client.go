@@ -8,10 +8,15 @@ import (
 	"fmt"
 	"github.com/rs/zerolog"
 	"io"
+	"net"
 	"net/http"
 	"time"
 )
 
+// ErrOutcomeUnknown — запрос на изменение мог быть выполнен WB, но подтверждения нет
+// (таймаут, обрыв соединения, 5xx). Повторять такой запрос вслепую нельзя — сначала нужно проверить состояние.
+var ErrOutcomeUnknown = errors.New("write outcome unknown")
+
 // WBClient — клиент для Wildberries Client
 type WBClient struct {
 	BaseURL map[string]string
@@ -37,6 +42,17 @@ func NewWBClient(tokens []string, log zerolog.Logger) *WBClient {
 	}
 }
 
+// tokenByIdx возвращает токен по 1-based индексу (как TokenIdx в ответах); 0 — первый токен
+func (c *WBClient) tokenByIdx(idx int) (string, error) {
+	if idx == 0 {
+		idx = 1
+	}
+	if idx < 1 || idx > len(c.Tokens) || c.Tokens[idx-1] == "" {
+		return "", fmt.Errorf("unknown token_idx %d (configured tokens: %d)", idx, len(c.Tokens))
+	}
+	return c.Tokens[idx-1], nil
+}
+
 // doRequest выполняет GET-запрос с retry и обработкой ошибок
 func (c *WBClient) doRequest(ctx context.Context, method, url, token string, payload any) ([]byte, error) {
 	const maxJSONSize = 20 << 20 // 20 MB
@@ -59,6 +75,13 @@ func (c *WBClient) doRequest(ctx context.Context, method, url, token string, pay
 
 	var resp *http.Response
 	for attempt := 1; attempt <= c.MaxRetries; attempt++ {
+		// тело запроса уже прочитано предыдущей попыткой — пересоздаём его
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
 		resp, err = c.Client.Do(req)
 		if err != nil {
 			if attempt < c.MaxRetries {
@@ -100,3 +123,59 @@ func (c *WBClient) doRequest(ctx context.Context, method, url, token string, pay
 
 	return nil, errors.New("max retries reached")
 }
+
+// doWrite выполняет запрос на изменение (деньги, статусы, поставки) ровно один раз — без retry.
+// Если запрос мог дойти до WB, но ответа об успехе нет, возвращается ошибка, оборачивающая ErrOutcomeUnknown.
+func (c *WBClient) doWrite(ctx context.Context, method, url, token string, payload any) ([]byte, error) {
+	const maxJSONSize = 20 << 20 // 20 MB
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("marshal payload: %w", err)
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		// соединение не установлено — запрос точно не отправлен
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return nil, fmt.Errorf("network error: %w", err)
+		}
+		return nil, fmt.Errorf("%w: network error: %v", ErrOutcomeUnknown, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 401:
+		return nil, fmt.Errorf("unauthorized (401)")
+	case resp.StatusCode == 429:
+		return nil, fmt.Errorf("too many requests (429)")
+	case resp.StatusCode >= 500:
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxJSONSize))
+		return nil, fmt.Errorf("%w: WB Client status %d: %s", ErrOutcomeUnknown, resp.StatusCode, string(b))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxJSONSize))
+		return nil, fmt.Errorf("unexpected WB Client status %d: %s", resp.StatusCode, string(b))
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxJSONSize))
+	if err != nil {
+		// WB ответил 2xx, но результат (например, новый бюджет) прочитать не удалось
+		return nil, fmt.Errorf("%w: read body error: %v", ErrOutcomeUnknown, err)
+	}
+	return b, nil
+}
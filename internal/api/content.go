@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// cardsPageLimit — максимальный limit для cards/list
+const cardsPageLimit = 100
+
+// CardDimensions — габариты упаковки товара (см, кг)
+type CardDimensions struct {
+	Length       float64 `json:"length"`
+	Width        float64 `json:"width"`
+	Height       float64 `json:"height"`
+	WeightBrutto float64 `json:"weightBrutto"`
+	IsValid      bool    `json:"isValid"`
+}
+
+// CardSize — размер товара и его баркоды
+type CardSize struct {
+	ChrtID   int64    `json:"chrtID"`
+	TechSize string   `json:"techSize"`
+	WbSize   string   `json:"wbSize"`
+	Skus     []string `json:"skus"`
+}
+
+// ProductCard — карточка товара из Content API
+type ProductCard struct {
+	NmID        int64          `json:"nmID"`
+	ImtID       int64          `json:"imtID"`
+	NmUUID      string         `json:"nmUUID"`
+	SubjectID   int            `json:"subjectID"`
+	SubjectName string         `json:"subjectName"`
+	VendorCode  string         `json:"vendorCode"`
+	Brand       string         `json:"brand"`
+	Title       string         `json:"title"`
+	Dimensions  CardDimensions `json:"dimensions"`
+	Sizes       []CardSize     `json:"sizes"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	TokenIdx    int            `json:"token_idx"`
+}
+
+// cardsCursor — курсор пагинации cards/list
+type cardsCursor struct {
+	UpdatedAt string `json:"updatedAt,omitempty"`
+	NmID      int64  `json:"nmID,omitempty"`
+	Total     int    `json:"total,omitempty"`
+}
+
+// GetCards получает все карточки товаров по каждому токену (курсорная пагинация).
+// Ошибка любой страницы возвращается: неполный каталог выглядел бы как удаление карточек.
+func (c *WBClient) GetCards(ctx context.Context) ([]ProductCard, error) {
+	all := make([]ProductCard, 0)
+
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+
+		cursor := cardsCursor{}
+		tokenTotal := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			reqCursor := map[string]any{"limit": cardsPageLimit}
+			if cursor.UpdatedAt != "" {
+				reqCursor["updatedAt"] = cursor.UpdatedAt
+				reqCursor["nmID"] = cursor.NmID
+			}
+			payload := map[string]any{
+				"settings": map[string]any{
+					"cursor": reqCursor,
+					"filter": map[string]any{"withPhoto": -1},
+				},
+			}
+
+			body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.ContentCards.URL, token, payload)
+			if err != nil {
+				return nil, fmt.Errorf("fetch cards (token_%d, nmID=%d): %w", idx+1, cursor.NmID, err)
+			}
+
+			var resp struct {
+				Cards  []ProductCard `json:"cards"`
+				Cursor cardsCursor   `json:"cursor"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("unmarshal cards (token_%d): %w", idx+1, err)
+			}
+
+			for i := range resp.Cards {
+				resp.Cards[i].TokenIdx = idx + 1
+			}
+			all = append(all, resp.Cards...)
+			tokenTotal += len(resp.Cards)
+
+			// последняя страница — total меньше лимита
+			if resp.Cursor.Total < cardsPageLimit || len(resp.Cards) == 0 {
+				break
+			}
+			cursor = resp.Cursor
+
+			// лимит Content API — 100 запросов в минуту
+			time.Sleep(600 * time.Millisecond)
+		}
+
+		c.Logger.Info().Msgf("✅ token_%d: %d product cards loaded", idx+1, tokenTotal)
+	}
+
+	return all, nil
+}
+
+// Barcodes возвращает все баркоды карточки
+func (p ProductCard) Barcodes() []string {
+	out := make([]string, 0)
+	for _, s := range p.Sizes {
+		out = append(out, s.Skus...)
+	}
+	return out
+}
+
+// VolumeLiters — объём упаковки в литрах по габаритам карточки
+func (p ProductCard) VolumeLiters() float64 {
+	d := p.Dimensions
+	return d.Length * d.Width * d.Height / 1000
+}
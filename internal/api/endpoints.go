@@ -1,12 +1,21 @@
 package api
 
 var WBBaseURLs = map[string]string{
-	"statistics": "https://statistics-api.wildberries.ru/api/v1/supplier",
-	"catalog":    "https://suppliers-api.wildberries.ru/api/v3",
-	"advert":     "https://advert-api.wb.ru/adv/v0",
-	"analytics":  "https://seller-analytics-api.wildberries.ru/api/v1/supplier",
-	"finance":    "https://suppliers-api.wildberries.ru/api/v2",
-	"search":     "https://catalog-analytics.wildberries.ru/api/v1",
+	"statistics":       "https://statistics-api.wildberries.ru/api/v1/supplier",
+	"catalog":          "https://suppliers-api.wildberries.ru/api/v3",
+	"advert":           "https://advert-api.wildberries.ru",
+	"analytics":        "https://seller-analytics-api.wildberries.ru/api/v1/supplier",
+	"seller_analytics": "https://seller-analytics-api.wildberries.ru/api/v1",
+	"nm_report":        "https://seller-analytics-api.wildberries.ru/api/v2/nm-report",
+	"search_report":    "https://seller-analytics-api.wildberries.ru/api/v2/search-report",
+	"analytics_v1":     "https://seller-analytics-api.wildberries.ru/api/analytics/v1",
+	"finance":          "https://suppliers-api.wildberries.ru/api/v2",
+	"search":           "https://catalog-analytics.wildberries.ru/api/v1",
+	"feedbacks":        "https://feedbacks-api.wildberries.ru/api/v1",
+	"content":          "https://content-api.wildberries.ru/content/v2",
+	"prices":           "https://discounts-prices-api.wildberries.ru/api/v2",
+	"marketplace":      "https://marketplace-api.wildberries.ru/api/v3",
+	"supplies":         "https://supplies-api.wildberries.ru/api/v1",
 }
 
 type WBEndpoint struct {
@@ -25,40 +34,136 @@ var WBEndpoints = struct {
 	PaidStorageStatus   WBEndpoint
 	PaidStorageDownload WBEndpoint
 
+	// === Async reports (seller analytics) ===
+	WarehouseRemainsStart    WBEndpoint
+	WarehouseRemainsStatus   WBEndpoint
+	WarehouseRemainsDownload WBEndpoint
+	AcceptanceReportStart    WBEndpoint
+	AcceptanceReportStatus   WBEndpoint
+	AcceptanceReportDownload WBEndpoint
+	MeasurementPenalties     WBEndpoint
+
+	// === Sales funnel (nm-report) ===
+	NMReportDetail         WBEndpoint
+	NMReportHistory        WBEndpoint
+	NMReportGrouped        WBEndpoint
+	NMReportGroupedHistory WBEndpoint
+	SearchTexts            WBEndpoint
+
 	// === Tariffs / Prices ===
-	Prices  WBEndpoint
-	Tariffs WBEndpoint
+	Prices             WBEndpoint
+	PricesUpload       WBEndpoint
+	PricesHistoryTasks WBEndpoint
+	PricesHistoryGoods WBEndpoint
+	PricesBufferTasks  WBEndpoint
+	PricesBufferGoods  WBEndpoint
+	Tariffs            WBEndpoint
 
 	// === Advertising ===
+	AdvertCount        WBEndpoint
 	AdvertCampaigns    WBEndpoint
 	AdvertFullStats    WBEndpoint
 	AdvertAutoStat     WBEndpoint
 	AdvertStatWords    WBEndpoint
 	AdvertKeywordsStat WBEndpoint
 
+	AdvertStart          WBEndpoint
+	AdvertPause          WBEndpoint
+	AdvertStop           WBEndpoint
+	AdvertBudget         WBEndpoint
+	AdvertBudgetDeposit  WBEndpoint
+	AdvertBids           WBEndpoint
+	AdvertSearchExcluded WBEndpoint
+	AdvertAutoExcluded   WBEndpoint
+
 	// === Finance ===
 	FinanceOps WBEndpoint
 	Returns    WBEndpoint
 	Supplies   WBEndpoint
+
+	// === Feedbacks & Questions ===
+	Feedbacks WBEndpoint
+	Questions WBEndpoint
+
+	// === Content ===
+	ContentCards WBEndpoint
+
+	// === Marketplace (FBS) ===
+	FBSNewOrders    WBEndpoint
+	FBSOrders       WBEndpoint
+	FBSOrdersStatus WBEndpoint
+	FBSStickers     WBEndpoint
+	FBSSupplies     WBEndpoint
+	FBSWarehouses   WBEndpoint
+	FBSStocks       WBEndpoint
+
+	// === Supplies (FBW) ===
+	AcceptanceCoefficients WBEndpoint
+	SupplyWarehouses       WBEndpoint
 }{
 	Sales:  WBEndpoint{"sales", WBBaseURLs["statistics"] + "/sales"},
 	Orders: WBEndpoint{"orders", WBBaseURLs["statistics"] + "/orders"},
 	Stocks: WBEndpoint{"stocks", WBBaseURLs["statistics"] + "/stocks"},
 
-	PaidStorageStart:    WBEndpoint{"paid_storage", WBBaseURLs["analytics"] + "/paidStorage"},
-	PaidStorageStatus:   WBEndpoint{"paid_storage_status", WBBaseURLs["analytics"] + "/paidStorage/status/%s"},
-	PaidStorageDownload: WBEndpoint{"paid_storage_download", WBBaseURLs["analytics"] + "/paidStorage/download/%s"},
+	PaidStorageStart:    WBEndpoint{"paid_storage", WBBaseURLs["seller_analytics"] + "/paid_storage"},
+	PaidStorageStatus:   WBEndpoint{"paid_storage_status", WBBaseURLs["seller_analytics"] + "/paid_storage/tasks/%s/status"},
+	PaidStorageDownload: WBEndpoint{"paid_storage_download", WBBaseURLs["seller_analytics"] + "/paid_storage/tasks/%s/download"},
 
-	Prices:  WBEndpoint{"prices", WBBaseURLs["catalog"] + "/prices"},
-	Tariffs: WBEndpoint{"tariffs", WBBaseURLs["catalog"] + "/tariffs"},
+	WarehouseRemainsStart:    WBEndpoint{"warehouse_remains", WBBaseURLs["seller_analytics"] + "/warehouse_remains"},
+	WarehouseRemainsStatus:   WBEndpoint{"warehouse_remains_status", WBBaseURLs["seller_analytics"] + "/warehouse_remains/tasks/%s/status"},
+	WarehouseRemainsDownload: WBEndpoint{"warehouse_remains_download", WBBaseURLs["seller_analytics"] + "/warehouse_remains/tasks/%s/download"},
+	AcceptanceReportStart:    WBEndpoint{"acceptance_report", WBBaseURLs["seller_analytics"] + "/acceptance_report"},
+	AcceptanceReportStatus:   WBEndpoint{"acceptance_report_status", WBBaseURLs["seller_analytics"] + "/acceptance_report/tasks/%s/status"},
+	AcceptanceReportDownload: WBEndpoint{"acceptance_report_download", WBBaseURLs["seller_analytics"] + "/acceptance_report/tasks/%s/download"},
+	MeasurementPenalties:     WBEndpoint{"measurement_penalties", WBBaseURLs["analytics_v1"] + "/measurement-penalties"},
 
-	AdvertCampaigns:    WBEndpoint{"advert_campaigns", WBBaseURLs["advert"] + "/campaigns"},
-	AdvertFullStats:    WBEndpoint{"advert_fullstats", WBBaseURLs["advert"] + "/fullstats"},
-	AdvertAutoStat:     WBEndpoint{"advert_auto_stat_words", WBBaseURLs["advert"] + "/auto/stat/words"},
-	AdvertStatWords:    WBEndpoint{"advert_stat_words", WBBaseURLs["advert"] + "/stat/words"},
-	AdvertKeywordsStat: WBEndpoint{"advert_keywords_stat", WBBaseURLs["advert"] + "/keywords/stats"},
+	NMReportDetail:         WBEndpoint{"nm_report_detail", WBBaseURLs["nm_report"] + "/detail"},
+	NMReportHistory:        WBEndpoint{"nm_report_history", WBBaseURLs["nm_report"] + "/detail/history"},
+	NMReportGrouped:        WBEndpoint{"nm_report_grouped", WBBaseURLs["nm_report"] + "/grouped"},
+	NMReportGroupedHistory: WBEndpoint{"nm_report_grouped_history", WBBaseURLs["nm_report"] + "/grouped/history"},
+	SearchTexts:            WBEndpoint{"search_texts", WBBaseURLs["search_report"] + "/product/search-texts"},
+
+	Prices:             WBEndpoint{"prices", WBBaseURLs["prices"] + "/list/goods/filter"},
+	PricesUpload:       WBEndpoint{"prices_upload", WBBaseURLs["prices"] + "/upload/task"},
+	PricesHistoryTasks: WBEndpoint{"prices_history_tasks", WBBaseURLs["prices"] + "/history/tasks"},
+	PricesHistoryGoods: WBEndpoint{"prices_history_goods", WBBaseURLs["prices"] + "/history/goods/task"},
+	PricesBufferTasks:  WBEndpoint{"prices_buffer_tasks", WBBaseURLs["prices"] + "/buffer/tasks"},
+	PricesBufferGoods:  WBEndpoint{"prices_buffer_goods", WBBaseURLs["prices"] + "/buffer/goods/task"},
+	Tariffs:            WBEndpoint{"tariffs", WBBaseURLs["catalog"] + "/tariffs"},
+
+	AdvertCount:        WBEndpoint{"advert_count", WBBaseURLs["advert"] + "/adv/v1/promotion/count"},
+	AdvertCampaigns:    WBEndpoint{"advert_campaigns", WBBaseURLs["advert"] + "/adv/v1/promotion/adverts"},
+	AdvertFullStats:    WBEndpoint{"advert_fullstats", WBBaseURLs["advert"] + "/adv/v2/fullstats"},
+	AdvertAutoStat:     WBEndpoint{"advert_auto_stat_words", WBBaseURLs["advert"] + "/adv/v2/auto/stat-words"},
+	AdvertStatWords:    WBEndpoint{"advert_stat_words", WBBaseURLs["advert"] + "/adv/v1/stat/words"},
+	AdvertKeywordsStat: WBEndpoint{"advert_keywords_stat", WBBaseURLs["advert"] + "/adv/v0/stats/keywords"},
+
+	AdvertStart:          WBEndpoint{"advert_start", WBBaseURLs["advert"] + "/adv/v0/start"},
+	AdvertPause:          WBEndpoint{"advert_pause", WBBaseURLs["advert"] + "/adv/v0/pause"},
+	AdvertStop:           WBEndpoint{"advert_stop", WBBaseURLs["advert"] + "/adv/v0/stop"},
+	AdvertBudget:         WBEndpoint{"advert_budget", WBBaseURLs["advert"] + "/adv/v1/budget"},
+	AdvertBudgetDeposit:  WBEndpoint{"advert_budget_deposit", WBBaseURLs["advert"] + "/adv/v1/budget/deposit"},
+	AdvertBids:           WBEndpoint{"advert_bids", WBBaseURLs["advert"] + "/adv/v0/bids"},
+	AdvertSearchExcluded: WBEndpoint{"advert_search_excluded", WBBaseURLs["advert"] + "/adv/v1/search/set-excluded"},
+	AdvertAutoExcluded:   WBEndpoint{"advert_auto_excluded", WBBaseURLs["advert"] + "/adv/v1/auto/set-excluded"},
 
 	FinanceOps: WBEndpoint{"finance_ops", WBBaseURLs["finance"] + "/finances/operations"},
 	Returns:    WBEndpoint{"returns", WBBaseURLs["finance"] + "/returns"},
 	Supplies:   WBEndpoint{"supplies", WBBaseURLs["finance"] + "/supplies"},
+
+	Feedbacks: WBEndpoint{"feedbacks", WBBaseURLs["feedbacks"] + "/feedbacks"},
+	Questions: WBEndpoint{"questions", WBBaseURLs["feedbacks"] + "/questions"},
+
+	ContentCards: WBEndpoint{"content_cards", WBBaseURLs["content"] + "/get/cards/list"},
+
+	FBSNewOrders:    WBEndpoint{"fbs_new_orders", WBBaseURLs["marketplace"] + "/orders/new"},
+	FBSOrders:       WBEndpoint{"fbs_orders", WBBaseURLs["marketplace"] + "/orders"},
+	FBSOrdersStatus: WBEndpoint{"fbs_orders_status", WBBaseURLs["marketplace"] + "/orders/status"},
+	FBSStickers:     WBEndpoint{"fbs_stickers", WBBaseURLs["marketplace"] + "/orders/stickers"},
+	FBSSupplies:     WBEndpoint{"fbs_supplies", WBBaseURLs["marketplace"] + "/supplies"},
+	FBSWarehouses:   WBEndpoint{"fbs_warehouses", WBBaseURLs["marketplace"] + "/warehouses"},
+	FBSStocks:       WBEndpoint{"fbs_stocks", WBBaseURLs["marketplace"] + "/stocks/%d"},
+
+	AcceptanceCoefficients: WBEndpoint{"acceptance_coefficients", WBBaseURLs["supplies"] + "/acceptance/coefficients"},
+	SupplyWarehouses:       WBEndpoint{"supply_warehouses", WBBaseURLs["supplies"] + "/warehouses"},
 }
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// feedbacksPageLimit — максимальный take для feedbacks/questions API
+const feedbacksPageLimit = 5000
+
+// feedbacksMaxSkip — WB не отдаёт записи дальше skip=199990
+const feedbacksMaxSkip = 199990
+
+// ProductDetails — товар, к которому относится отзыв или вопрос
+type ProductDetails struct {
+	NmID            int64  `json:"nmId"`
+	ImtID           int64  `json:"imtId"`
+	ProductName     string `json:"productName"`
+	SupplierArticle string `json:"supplierArticle"`
+	SupplierName    string `json:"supplierName,omitempty"`
+	BrandName       string `json:"brandName"`
+	Size            string `json:"size,omitempty"`
+}
+
+// FeedbackAnswer — ответ продавца на отзыв
+type FeedbackAnswer struct {
+	Text     string `json:"text"`
+	State    string `json:"state"`
+	Editable bool   `json:"editable"`
+}
+
+// Feedback — отзыв покупателя
+type Feedback struct {
+	ID               string          `json:"id"`
+	Text             string          `json:"text"`
+	Pros             string          `json:"pros"`
+	Cons             string          `json:"cons"`
+	ProductValuation int             `json:"productValuation"`
+	CreatedDate      time.Time       `json:"createdDate"`
+	State            string          `json:"state"`
+	Answer           *FeedbackAnswer `json:"answer"`
+	ProductDetails   ProductDetails  `json:"productDetails"`
+	WasViewed        bool            `json:"wasViewed"`
+	UserName         string          `json:"userName"`
+	TokenIdx         int             `json:"token_idx"`
+}
+
+// QuestionAnswer — ответ продавца на вопрос
+type QuestionAnswer struct {
+	Text       string    `json:"text"`
+	Editable   bool      `json:"editable"`
+	CreateDate time.Time `json:"createDate"`
+}
+
+// Question — вопрос покупателя
+type Question struct {
+	ID             string          `json:"id"`
+	Text           string          `json:"text"`
+	CreatedDate    time.Time       `json:"createdDate"`
+	State          string          `json:"state"`
+	Answer         *QuestionAnswer `json:"answer"`
+	ProductDetails ProductDetails  `json:"productDetails"`
+	WasViewed      bool            `json:"wasViewed"`
+	IsWarned       bool            `json:"isWarned"`
+	TokenIdx       int             `json:"token_idx"`
+}
+
+// FeedbackQuery — фильтр для выборки отзывов и вопросов
+type FeedbackQuery struct {
+	// TokenIdx — выбрать только этот токен (0 — все токены)
+	TokenIdx   int
+	IsAnswered bool
+	NmID       int64
+	DateFrom   time.Time
+	DateTo     time.Time
+	// Order — "dateAsc" или "dateDesc" (по умолчанию dateDesc)
+	Order string
+	// Limit — ограничение общего числа записей на токен (0 — без ограничения)
+	Limit int
+}
+
+func (q FeedbackQuery) values(take, skip int) url.Values {
+	v := url.Values{}
+	v.Set("isAnswered", strconv.FormatBool(q.IsAnswered))
+	v.Set("take", strconv.Itoa(take))
+	v.Set("skip", strconv.Itoa(skip))
+	order := q.Order
+	if order == "" {
+		order = "dateDesc"
+	}
+	v.Set("order", order)
+	if q.NmID != 0 {
+		v.Set("nmId", strconv.FormatInt(q.NmID, 10))
+	}
+	if !q.DateFrom.IsZero() {
+		v.Set("dateFrom", strconv.FormatInt(q.DateFrom.Unix(), 10))
+	}
+	if !q.DateTo.IsZero() {
+		v.Set("dateTo", strconv.FormatInt(q.DateTo.Unix(), 10))
+	}
+	return v
+}
+
+// feedbacksEnvelope — общий формат ответа feedbacks API
+type feedbacksEnvelope struct {
+	Data             json.RawMessage `json:"data"`
+	Error            bool            `json:"error"`
+	ErrorText        string          `json:"errorText"`
+	AdditionalErrors []string        `json:"additionalErrors"`
+}
+
+// fetchFeedbackPages листает страницы take/skip и отдаёт data каждой страницы в decode.
+// decode возвращает количество записей на странице.
+func (c *WBClient) fetchFeedbackPages(ctx context.Context, endpoint, token string, q FeedbackQuery, decode func(data json.RawMessage) (int, error)) error {
+	take := feedbacksPageLimit
+	if q.Limit > 0 && q.Limit < take {
+		take = q.Limit
+	}
+
+	total := 0
+	for skip := 0; skip <= feedbacksMaxSkip; skip += take {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		reqURL := endpoint + "?" + q.values(take, skip).Encode()
+		body, err := c.doRequest(ctx, http.MethodGet, reqURL, token, nil)
+		if err != nil {
+			return err
+		}
+
+		var env feedbacksEnvelope
+		if err := json.Unmarshal(body, &env); err != nil {
+			return fmt.Errorf("unmarshal feedbacks envelope: %w", err)
+		}
+		if env.Error {
+			return fmt.Errorf("feedbacks API error: %s", env.ErrorText)
+		}
+
+		n, err := decode(env.Data)
+		if err != nil {
+			return err
+		}
+		total += n
+
+		if n < take || (q.Limit > 0 && total >= q.Limit) {
+			return nil
+		}
+
+		// лимит feedbacks API — 3 запроса в секунду
+		time.Sleep(400 * time.Millisecond)
+	}
+	return nil
+}
+
+// GetFeedbacks получает отзывы по всем токенам (или по q.TokenIdx) постранично.
+// Ошибки отдельных токенов собираются в одну; записи успешных токенов возвращаются вместе с ней.
+func (c *WBClient) GetFeedbacks(ctx context.Context, q FeedbackQuery) ([]Feedback, error) {
+	all := make([]Feedback, 0)
+	var errs []error
+
+	for idx, token := range c.Tokens {
+		if token == "" || (q.TokenIdx != 0 && q.TokenIdx != idx+1) {
+			continue
+		}
+
+		count := 0
+		err := c.fetchFeedbackPages(ctx, WBEndpoints.Feedbacks.URL, token, q, func(data json.RawMessage) (int, error) {
+			var page struct {
+				Feedbacks []Feedback `json:"feedbacks"`
+			}
+			if err := json.Unmarshal(data, &page); err != nil {
+				return 0, fmt.Errorf("unmarshal feedbacks: %w", err)
+			}
+			for i := range page.Feedbacks {
+				page.Feedbacks[i].TokenIdx = idx + 1
+			}
+			all = append(all, page.Feedbacks...)
+			count += len(page.Feedbacks)
+			return len(page.Feedbacks), nil
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return all, ctx.Err()
+			}
+			c.Logger.Error().Err(err).Msgf("❌ failed to fetch feedbacks (token_%d)", idx+1)
+			errs = append(errs, fmt.Errorf("token_%d: %w", idx+1, err))
+			continue
+		}
+
+		c.Logger.Info().Msgf("✅ token_%d: feedbacks loaded (%d records, answered=%t)", idx+1, count, q.IsAnswered)
+	}
+
+	return all, errors.Join(errs...)
+}
+
+// GetQuestions получает вопросы покупателей по всем токенам (или по q.TokenIdx) постранично.
+// Ошибки отдельных токенов собираются в одну; записи успешных токенов возвращаются вместе с ней.
+func (c *WBClient) GetQuestions(ctx context.Context, q FeedbackQuery) ([]Question, error) {
+	all := make([]Question, 0)
+	var errs []error
+
+	for idx, token := range c.Tokens {
+		if token == "" || (q.TokenIdx != 0 && q.TokenIdx != idx+1) {
+			continue
+		}
+
+		count := 0
+		err := c.fetchFeedbackPages(ctx, WBEndpoints.Questions.URL, token, q, func(data json.RawMessage) (int, error) {
+			var page struct {
+				Questions []Question `json:"questions"`
+			}
+			if err := json.Unmarshal(data, &page); err != nil {
+				return 0, fmt.Errorf("unmarshal questions: %w", err)
+			}
+			for i := range page.Questions {
+				page.Questions[i].TokenIdx = idx + 1
+			}
+			all = append(all, page.Questions...)
+			count += len(page.Questions)
+			return len(page.Questions), nil
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return all, ctx.Err()
+			}
+			c.Logger.Error().Err(err).Msgf("❌ failed to fetch questions (token_%d)", idx+1)
+			errs = append(errs, fmt.Errorf("token_%d: %w", idx+1, err))
+			continue
+		}
+
+		c.Logger.Info().Msgf("✅ token_%d: questions loaded (%d records, answered=%t)", idx+1, count, q.IsAnswered)
+	}
+
+	return all, errors.Join(errs...)
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetFeedback получает один отзыв по ID (нужен для подстановки шаблона ответа)
+func (c *WBClient) GetFeedback(ctx context.Context, tokenIdx int, id string) (*Feedback, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := WBBaseURLs["feedbacks"] + "/feedback?" + url.Values{"id": {id}}.Encode()
+	body, err := c.doRequest(ctx, http.MethodGet, reqURL, token, nil)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to get feedback id=%s", id)
+		return nil, err
+	}
+
+	var env feedbacksEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal feedback envelope: %w", err)
+	}
+	if env.Error {
+		return nil, fmt.Errorf("feedbacks API error: %s", env.ErrorText)
+	}
+
+	var f Feedback
+	if err := json.Unmarshal(env.Data, &f); err != nil {
+		return nil, fmt.Errorf("unmarshal feedback: %w", err)
+	}
+	f.TokenIdx = tokenIdx
+	return &f, nil
+}
+
+// GetQuestion получает один вопрос по ID
+func (c *WBClient) GetQuestion(ctx context.Context, tokenIdx int, id string) (*Question, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := WBBaseURLs["feedbacks"] + "/question?" + url.Values{"id": {id}}.Encode()
+	body, err := c.doRequest(ctx, http.MethodGet, reqURL, token, nil)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to get question id=%s", id)
+		return nil, err
+	}
+
+	var env feedbacksEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal question envelope: %w", err)
+	}
+	if env.Error {
+		return nil, fmt.Errorf("feedbacks API error: %s", env.ErrorText)
+	}
+
+	var q Question
+	if err := json.Unmarshal(env.Data, &q); err != nil {
+		return nil, fmt.Errorf("unmarshal question: %w", err)
+	}
+	q.TokenIdx = tokenIdx
+	return &q, nil
+}
+
+// sendFeedbackWrite выполняет запись в feedbacks API без retry и проверяет флаг error в ответе.
+// Повтор после таймаута мог бы отправить ответ дважды или получить 4xx «уже отвечено» при опубликованном ответе.
+func (c *WBClient) sendFeedbackWrite(ctx context.Context, method, endpoint string, tokenIdx int, payload any) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.doWrite(ctx, method, endpoint, token, payload)
+	if err != nil {
+		return err
+	}
+
+	// на успешную запись WB чаще всего отвечает 204 без тела
+	if len(body) == 0 {
+		return nil
+	}
+	var env feedbacksEnvelope
+	if err := json.Unmarshal(body, &env); err == nil && env.Error {
+		return errors.New("feedbacks API error: " + env.ErrorText)
+	}
+	return nil
+}
+
+// AnswerFeedback отправляет ответ на отзыв
+func (c *WBClient) AnswerFeedback(ctx context.Context, tokenIdx int, id, text string) error {
+	payload := map[string]string{"id": id, "text": text}
+	if err := c.sendFeedbackWrite(ctx, http.MethodPost, WBEndpoints.Feedbacks.URL+"/answer", tokenIdx, payload); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to answer feedback id=%s", id)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ feedback answered: id=%s (token_%d)", id, tokenIdx)
+	return nil
+}
+
+// EditFeedbackAnswer редактирует ответ на отзыв (WB разрешает это один раз в течение 60 дней)
+func (c *WBClient) EditFeedbackAnswer(ctx context.Context, tokenIdx int, id, text string) error {
+	payload := map[string]string{"id": id, "text": text}
+	if err := c.sendFeedbackWrite(ctx, http.MethodPatch, WBEndpoints.Feedbacks.URL+"/answer", tokenIdx, payload); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to edit feedback answer id=%s", id)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ feedback answer edited: id=%s (token_%d)", id, tokenIdx)
+	return nil
+}
+
+// MarkFeedbackViewed отмечает отзыв просмотренным
+func (c *WBClient) MarkFeedbackViewed(ctx context.Context, tokenIdx int, id string) error {
+	payload := map[string]any{"id": id, "wasViewed": true}
+	if err := c.sendFeedbackWrite(ctx, http.MethodPatch, WBEndpoints.Feedbacks.URL, tokenIdx, payload); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to mark feedback viewed id=%s", id)
+		return err
+	}
+	return nil
+}
+
+// AnswerQuestion отправляет ответ на вопрос
+func (c *WBClient) AnswerQuestion(ctx context.Context, tokenIdx int, id, text string) error {
+	payload := map[string]any{
+		"id":     id,
+		"answer": map[string]string{"text": text},
+		"state":  "wbRu",
+	}
+	if err := c.sendFeedbackWrite(ctx, http.MethodPatch, WBEndpoints.Questions.URL, tokenIdx, payload); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to answer question id=%s", id)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ question answered: id=%s (token_%d)", id, tokenIdx)
+	return nil
+}
+
+// EditQuestionAnswer редактирует ответ на вопрос.
+// У WB это тот же PATCH, что и ответ: новый текст заменяет предыдущий.
+func (c *WBClient) EditQuestionAnswer(ctx context.Context, tokenIdx int, id, text string) error {
+	return c.AnswerQuestion(ctx, tokenIdx, id, text)
+}
+
+// MarkQuestionViewed отмечает вопрос просмотренным
+func (c *WBClient) MarkQuestionViewed(ctx context.Context, tokenIdx int, id string) error {
+	payload := map[string]any{"id": id, "wasViewed": true}
+	if err := c.sendFeedbackWrite(ctx, http.MethodPatch, WBEndpoints.Questions.URL, tokenIdx, payload); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to mark question viewed id=%s", id)
+		return err
+	}
+	return nil
+}
@@ -27,12 +27,12 @@ func (c *WBClient) GetFinanceOperations(ctx context.Context, dateFrom, dateTo st
 		"limit":    "1000",
 	}
 
-	for _, token := range c.Tokens {
+	for idx, token := range c.Tokens {
 		url := WBBaseURLs["finance"] + "/api/v1/supplier/finances/operations"
 
 		body, err := c.doRequest(ctx, "GET", url, token, params)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("finance ops failed for supplier=%d")
+			c.Logger.Error().Err(err).Msgf("finance ops failed for token_%d", idx+1)
 			continue
 		}
 
@@ -60,12 +60,12 @@ func (c *WBClient) GetReturns(ctx context.Context, dateFrom, dateTo string) ([]R
 		"dateTo":   dateTo,
 	}
 
-	for _, token := range c.Tokens {
+	for idx, token := range c.Tokens {
 		url := WBBaseURLs["returns"] + "/api/v1/supplier/returns"
 
 		body, err := c.doRequest(ctx, "GET", url, token, params)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("returns failed for supplier=%d")
+			c.Logger.Error().Err(err).Msgf("returns failed for token_%d", idx+1)
 			continue
 		}
 
@@ -90,12 +90,12 @@ func (c *WBClient) GetSupplies(ctx context.Context, limit int) ([]SupplyItem, er
 	all := []SupplyItem{}
 	params := map[string]string{"limit": fmt.Sprint(limit)}
 
-	for _, token := range c.Tokens {
+	for idx, token := range c.Tokens {
 		url := WBBaseURLs["supplies"] + "/api/v1/supplier/supplies"
 
 		body, err := c.doRequest(ctx, "GET", url, token, params)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("supplies failed for supplier=%d")
+			c.Logger.Error().Err(err).Msgf("supplies failed for token_%d", idx+1)
 			continue
 		}
 
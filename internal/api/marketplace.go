@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// fbsStatusBatch — максимум заказов в запросе статусов
+	fbsStatusBatch = 1000
+	// fbsStickersBatch — максимум заказов в запросе этикеток
+	fbsStickersBatch = 100
+)
+
+// FBSOrder — сборочное задание (заказ FBS)
+type FBSOrder struct {
+	ID             int64     `json:"id"`
+	Rid            string    `json:"rid"`
+	OrderUID       string    `json:"orderUid"`
+	CreatedAt      time.Time `json:"createdAt"`
+	WarehouseID    int64     `json:"warehouseId"`
+	SupplyID       string    `json:"supplyId,omitempty"`
+	Offices        []string  `json:"offices"`
+	Skus           []string  `json:"skus"`
+	Price          int       `json:"price"`
+	ConvertedPrice int       `json:"convertedPrice"`
+	CurrencyCode   int       `json:"currencyCode"`
+	NmID           int64     `json:"nmId"`
+	ChrtID         int64     `json:"chrtId"`
+	Article        string    `json:"article"`
+	CargoType      int       `json:"cargoType"`
+	DeliveryType   string    `json:"deliveryType"`
+	TokenIdx       int       `json:"token_idx"`
+}
+
+// FBSOrderStatus — статус сборочного задания
+type FBSOrderStatus struct {
+	ID             int64  `json:"id"`
+	SupplierStatus string `json:"supplierStatus"` // new, confirm, complete, cancel
+	WbStatus       string `json:"wbStatus"`       // waiting, sorted, sold, canceled…
+}
+
+// FBSSupply — поставка FBS
+type FBSSupply struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Done      bool       `json:"done"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+	ScanDt    *time.Time `json:"scanDt"`
+	CargoType int        `json:"cargoType"`
+	TokenIdx  int        `json:"token_idx"`
+}
+
+// FBSSticker — этикетка сборочного задания
+type FBSSticker struct {
+	OrderID int64  `json:"orderId"`
+	PartA   int64  `json:"partA"`
+	PartB   int64  `json:"partB"`
+	Barcode string `json:"barcode"`
+	File    string `json:"file"` // base64
+}
+
+// GetNewFBSOrders получает новые сборочные задания по всем токенам
+func (c *WBClient) GetNewFBSOrders(ctx context.Context) ([]FBSOrder, error) {
+	all := make([]FBSOrder, 0)
+
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+
+		body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.FBSNewOrders.URL, token, nil)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to fetch new FBS orders (token_%d)", idx+1)
+			continue
+		}
+
+		var resp struct {
+			Orders []FBSOrder `json:"orders"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			c.Logger.Error().Err(err).Msg("unmarshal error in GetNewFBSOrders response")
+			continue
+		}
+
+		for i := range resp.Orders {
+			resp.Orders[i].TokenIdx = idx + 1
+		}
+		all = append(all, resp.Orders...)
+	}
+
+	return all, nil
+}
+
+// GetFBSOrders получает сборочные задания за период (пагинация через next)
+func (c *WBClient) GetFBSOrders(ctx context.Context, tokenIdx int, dateFrom, dateTo time.Time) ([]FBSOrder, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]FBSOrder, 0)
+	next := int64(0)
+	for {
+		q := url.Values{}
+		q.Set("limit", "1000")
+		q.Set("next", strconv.FormatInt(next, 10))
+		q.Set("dateFrom", strconv.FormatInt(dateFrom.Unix(), 10))
+		q.Set("dateTo", strconv.FormatInt(dateTo.Unix(), 10))
+
+		body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.FBSOrders.URL+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Next   int64      `json:"next"`
+			Orders []FBSOrder `json:"orders"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal FBS orders: %w", err)
+		}
+		for i := range resp.Orders {
+			resp.Orders[i].TokenIdx = tokenIdx
+		}
+		all = append(all, resp.Orders...)
+
+		if len(resp.Orders) == 0 || resp.Next == 0 || resp.Next == next {
+			return all, nil
+		}
+		next = resp.Next
+		time.Sleep(300 * time.Millisecond)
+	}
+}
+
+// GetFBSOrderStatuses получает статусы сборочных заданий пачками по 1000
+func (c *WBClient) GetFBSOrderStatuses(ctx context.Context, tokenIdx int, orderIDs []int64) ([]FBSOrderStatus, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]FBSOrderStatus, 0, len(orderIDs))
+	for _, batch := range chunkInt64Slice(orderIDs, fbsStatusBatch) {
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.FBSOrdersStatus.URL, token, map[string]any{"orders": batch})
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Orders []FBSOrderStatus `json:"orders"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal FBS order statuses: %w", err)
+		}
+		all = append(all, resp.Orders...)
+	}
+	return all, nil
+}
+
+// GetFBSSupplies получает список поставок FBS
+func (c *WBClient) GetFBSSupplies(ctx context.Context, tokenIdx int) ([]FBSSupply, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]FBSSupply, 0)
+	next := int64(0)
+	for {
+		q := url.Values{}
+		q.Set("limit", "1000")
+		q.Set("next", strconv.FormatInt(next, 10))
+
+		body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.FBSSupplies.URL+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Next     int64       `json:"next"`
+			Supplies []FBSSupply `json:"supplies"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal FBS supplies: %w", err)
+		}
+		for i := range resp.Supplies {
+			resp.Supplies[i].TokenIdx = tokenIdx
+		}
+		all = append(all, resp.Supplies...)
+
+		if len(resp.Supplies) == 0 || resp.Next == 0 || resp.Next == next {
+			return all, nil
+		}
+		next = resp.Next
+	}
+}
+
+// CreateFBSSupply создаёт новую поставку и возвращает её ID
+func (c *WBClient) CreateFBSSupply(ctx context.Context, tokenIdx int, name string) (string, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.doWrite(ctx, http.MethodPost, WBEndpoints.FBSSupplies.URL, token, map[string]string{"name": name})
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to create FBS supply %q", name)
+		return "", err
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		// поставка создана, но её ID неизвестен
+		return "", fmt.Errorf("%w: supply created, unmarshal FBS supply: %v", ErrOutcomeUnknown, err)
+	}
+	c.Logger.Info().Msgf("✅ FBS supply created: %s (token_%d)", resp.ID, tokenIdx)
+	return resp.ID, nil
+}
+
+// AddOrderToFBSSupply добавляет сборочное задание в поставку (задание переходит в статус confirm)
+func (c *WBClient) AddOrderToFBSSupply(ctx context.Context, tokenIdx int, supplyID string, orderID int64) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	reqURL := fmt.Sprintf("%s/%s/orders/%d", WBEndpoints.FBSSupplies.URL, url.PathEscape(supplyID), orderID)
+	if _, err := c.doWrite(ctx, http.MethodPatch, reqURL, token, nil); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to add order %d to supply %s", orderID, supplyID)
+		return err
+	}
+	return nil
+}
+
+// DeliverFBSSupply передаёт поставку в доставку
+func (c *WBClient) DeliverFBSSupply(ctx context.Context, tokenIdx int, supplyID string) error {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return err
+	}
+
+	reqURL := fmt.Sprintf("%s/%s/deliver", WBEndpoints.FBSSupplies.URL, url.PathEscape(supplyID))
+	if _, err := c.doWrite(ctx, http.MethodPatch, reqURL, token, nil); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to deliver supply %s", supplyID)
+		return err
+	}
+	c.Logger.Info().Msgf("✅ FBS supply %s sent to delivery", supplyID)
+	return nil
+}
+
+// GetFBSStickers получает этикетки сборочных заданий пачками по 100.
+// stickerType — svg, zplv, zplh или png; size — "58x40" или "40x30".
+func (c *WBClient) GetFBSStickers(ctx context.Context, tokenIdx int, orderIDs []int64, stickerType, size string) ([]FBSSticker, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := "58", "40"
+	if size == "40x30" {
+		width, height = "40", "30"
+	}
+	q := url.Values{}
+	q.Set("type", stickerType)
+	q.Set("width", width)
+	q.Set("height", height)
+
+	all := make([]FBSSticker, 0, len(orderIDs))
+	for _, batch := range chunkInt64Slice(orderIDs, fbsStickersBatch) {
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.FBSStickers.URL+"?"+q.Encode(), token, map[string]any{"orders": batch})
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Stickers []FBSSticker `json:"stickers"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal FBS stickers: %w", err)
+		}
+		all = append(all, resp.Stickers...)
+	}
+	return all, nil
+}
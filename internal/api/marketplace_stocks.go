@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// fbsStocksBatch — максимум SKU в одном запросе остатков
+const fbsStocksBatch = 1000
+
+// FBSWarehouse — склад продавца
+type FBSWarehouse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	OfficeID     int64  `json:"officeId"`
+	CargoType    int    `json:"cargoType"`
+	DeliveryType int    `json:"deliveryType"`
+	TokenIdx     int    `json:"token_idx"`
+}
+
+// FBSStock — остаток по SKU (баркоду) на складе продавца
+type FBSStock struct {
+	Sku    string `json:"sku"`
+	Amount int    `json:"amount"`
+}
+
+// GetFBSWarehouses получает список складов продавца
+func (c *WBClient) GetFBSWarehouses(ctx context.Context, tokenIdx int) ([]FBSWarehouse, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.FBSWarehouses.URL, token, nil)
+	if err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to fetch FBS warehouses (token_%d)", tokenIdx)
+		return nil, err
+	}
+
+	var warehouses []FBSWarehouse
+	if err := json.Unmarshal(body, &warehouses); err != nil {
+		return nil, fmt.Errorf("unmarshal FBS warehouses: %w", err)
+	}
+	for i := range warehouses {
+		warehouses[i].TokenIdx = tokenIdx
+	}
+	return warehouses, nil
+}
+
+// GetFBSStocks получает остатки по списку SKU на складе продавца пачками по 1000.
+// SKU без остатка WB в ответ не включает.
+func (c *WBClient) GetFBSStocks(ctx context.Context, tokenIdx int, warehouseID int64, skus []string) ([]FBSStock, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf(WBEndpoints.FBSStocks.URL, warehouseID)
+	all := make([]FBSStock, 0, len(skus))
+	for start := 0; start < len(skus); start += fbsStocksBatch {
+		end := start + fbsStocksBatch
+		if end > len(skus) {
+			end = len(skus)
+		}
+
+		body, err := c.doRequest(ctx, http.MethodPost, reqURL, token, map[string]any{"skus": skus[start:end]})
+		if err != nil {
+			return all, err
+		}
+
+		var resp struct {
+			Stocks []FBSStock `json:"stocks"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return all, fmt.Errorf("unmarshal FBS stocks: %w", err)
+		}
+		all = append(all, resp.Stocks...)
+	}
+	return all, nil
+}
+
+// UpdateFBSStocks обновляет остатки на складе продавца пачками по 1000 и возвращает число применённых SKU:
+// при ошибке пачки предыдущие пачки в WB уже записаны
+func (c *WBClient) UpdateFBSStocks(ctx context.Context, tokenIdx int, warehouseID int64, stocks []FBSStock) (int, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return 0, err
+	}
+
+	reqURL := fmt.Sprintf(WBEndpoints.FBSStocks.URL, warehouseID)
+	for start := 0; start < len(stocks); start += fbsStocksBatch {
+		end := start + fbsStocksBatch
+		if end > len(stocks) {
+			end = len(stocks)
+		}
+
+		if _, err := c.doRequest(ctx, http.MethodPut, reqURL, token, map[string]any{"stocks": stocks[start:end]}); err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to update FBS stocks on warehouse %d (%d of %d SKU applied)", warehouseID, start, len(stocks))
+			return start, err
+		}
+	}
+	c.Logger.Info().Msgf("✅ FBS stocks updated: warehouse %d, %d SKU", warehouseID, len(stocks))
+	return len(stocks), nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// measurementPenaltiesLimit — максимум строк на страницу отчёта об удержаниях за габариты
+const measurementPenaltiesLimit = 1000
+
+// MeasurementPenalty — удержание за занижение габаритов упаковки: замер склада WB против заявленных продавцом размеров.
+// В отличие от платного хранения и приёмки, WB отдаёт этот отчёт синхронно, постранично (limit/offset).
+type MeasurementPenalty struct {
+	NmID          int64    `json:"nmId"`
+	Subject       string   `json:"subject"`
+	DimID         int64    `json:"dimId"`   // ID замера — уникален, по нему удобно дедуплицировать
+	PrcOver       float64  `json:"prcOver"` // превышение объёма, %
+	Volume        float64  `json:"volume"`  // объём по замеру WB, л
+	Width         float64  `json:"width"`   // размеры по замеру WB, см
+	Length        float64  `json:"length"`
+	Height        float64  `json:"height"`
+	VolumeSup     float64  `json:"volumeSup"` // объём и размеры, указанные продавцом
+	WidthSup      float64  `json:"widthSup"`
+	LengthSup     float64  `json:"lengthSup"`
+	HeightSup     float64  `json:"heightSup"`
+	PhotoURLs     []string `json:"photoUrls"`
+	DtBonus       string   `json:"dtBonus"` // дата удержания
+	IsValid       bool     `json:"isValid"` // замер подтверждён
+	IsValidDt     string   `json:"isValidDt"`
+	PenaltyAmount float64  `json:"penaltyAmount"` // сумма удержания, ₽
+
+	TokenIdx int `json:"token_idx"`
+}
+
+// GetMeasurementPenalties получает удержания за занижение габаритов за период по одному токену (все страницы).
+// Ошибка любой страницы возвращается — неполный отчёт не отдаётся.
+func (c *WBClient) GetMeasurementPenalties(ctx context.Context, tokenIdx int, from, to time.Time) ([]MeasurementPenalty, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+	if tokenIdx == 0 {
+		tokenIdx = 1
+	}
+
+	out := make([]MeasurementPenalty, 0)
+	for offset := 0; ; offset += measurementPenaltiesLimit {
+		q := url.Values{}
+		q.Set("dateFrom", from.UTC().Format(time.RFC3339))
+		q.Set("dateTo", to.UTC().Format(time.RFC3339))
+		q.Set("limit", strconv.Itoa(measurementPenaltiesLimit))
+		q.Set("offset", strconv.Itoa(offset))
+
+		body, err := c.doRequest(ctx, http.MethodGet, WBEndpoints.MeasurementPenalties.URL+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return nil, fmt.Errorf("measurement penalties (token_%d, offset %d): %w", tokenIdx, offset, err)
+		}
+
+		var resp struct {
+			Data struct {
+				Reports []MeasurementPenalty `json:"reports"`
+				Total   int                  `json:"total"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal measurement penalties: %w", err)
+		}
+
+		for _, p := range resp.Data.Reports {
+			p.TokenIdx = tokenIdx
+			out = append(out, p)
+		}
+		if len(resp.Data.Reports) < measurementPenaltiesLimit || offset+len(resp.Data.Reports) >= resp.Data.Total {
+			break
+		}
+	}
+
+	c.Logger.Info().Msgf("✅ Measurement penalties (token_%d, %s..%s): %d records", tokenIdx, from.Format("2006-01-02"), to.Format("2006-01-02"), len(out))
+	return out, nil
+}
+
+// GetAllMeasurementPenalties получает удержания за габариты по всем токенам; ошибка любого токена возвращается
+func (c *WBClient) GetAllMeasurementPenalties(ctx context.Context, from, to time.Time) ([]MeasurementPenalty, error) {
+	out := make([]MeasurementPenalty, 0)
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+		rows, err := c.GetMeasurementPenalties(ctx, idx+1, from, to)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rows...)
+	}
+	return out, nil
+}
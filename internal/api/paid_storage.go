@@ -2,107 +2,43 @@ package api
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"time"
+	"net/url"
 )
 
 // PaidStorageTask — результат запуска задачи WB "платное хранение"
-type PaidStorageTask struct {
-	TokenIdx   int    `json:"token_idx"`
-	SupplierID int    `json:"supplier_id"`
-	TaskID     string `json:"task_id"`
-}
+type PaidStorageTask = ReportTask
 
 // PaidStorageStatus — структура ответа при проверке статуса
-type PaidStorageStatus struct {
-	Data struct {
-		State   string `json:"state"`
-		Percent int    `json:"percent"`
-	} `json:"data"`
-}
+type PaidStorageStatus = ReportTaskStatus
 
 // StartPaidStorage запускает сбор данных о платном хранении
 func (c *WBClient) StartPaidStorage(ctx context.Context, dateFrom, dateTo string) ([]PaidStorageTask, error) {
-	results := make([]PaidStorageTask, 0)
-	params := map[string]string{"dateFrom": dateFrom, "dateTo": dateTo}
-
-	for idx, token := range c.Tokens {
-		if token == "" {
-			continue
-		}
-
-		url := fmt.Sprintf("https://seller-analytics-api.wildberries.ru/api/v1/paid_storage?dateFrom=%s&dateTo=%s", dateFrom, dateTo)
-		body, err := c.doRequest(ctx, "GET", url, token, params)
-		if err != nil {
-			c.Logger.Error().Err(err).Msgf("❌ Failed to start paid_storage ")
-			continue
-		}
-
-		var resp struct {
-			Data struct {
-				TaskID string `json:"taskId"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(body, &resp); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal error in start_paid_storage response")
-			continue
-		}
+	return c.StartReportTasks(ctx, PaidStorageReport, periodQuery(dateFrom, dateTo)), nil
+}
 
-		if resp.Data.TaskID != "" {
-			results = append(results, PaidStorageTask{
-				TokenIdx: idx + 1,
-				TaskID:   resp.Data.TaskID,
-			})
-			c.Logger.Info().Msgf("✅ paid_storage started: token_%d, task_id=%s",
-				idx+1, resp.Data.TaskID)
-		} else {
-			c.Logger.Warn().Msgf("⚠️ Unexpected paid_storage start response for")
-		}
-	}
+// StartPaidStorageTask создаёт задачу на отчёт о платном хранении для одного токена (период — до 8 дней)
+func (c *WBClient) StartPaidStorageTask(ctx context.Context, tokenIdx int, dateFrom, dateTo string) (string, error) {
+	return c.StartReportTask(ctx, tokenIdx, PaidStorageReport, periodQuery(dateFrom, dateTo))
+}
 
-	return results, nil
+// periodQuery — параметры dateFrom/dateTo для отчётов за период
+func periodQuery(dateFrom, dateTo string) url.Values {
+	q := url.Values{}
+	q.Set("dateFrom", dateFrom)
+	q.Set("dateTo", dateTo)
+	return q
 }
 
 // GetPaidStorageStatus проверяет статус задачи по token_idx и task_id
-func (c *WBClient) GetPaidStorageStatus(ctx context.Context, taskID string) (*PaidStorageStatus, error) {
-
-	url := fmt.Sprintf("https://seller-analytics-api.wildberries.ru/api/v1/paid_storage/tasks/%s/status", taskID)
-
-	body, err := c.doRequest(ctx, "GET", url, c.Tokens[0], nil)
-	if err != nil {
-		c.Logger.Error().Err(err).Msgf("❌ Failed to get paid_storage status ( task_id=%s)", taskID)
-		return nil, err
-	}
-
-	var status PaidStorageStatus
-	if err := json.Unmarshal(body, &status); err != nil {
-		return nil, fmt.Errorf("unmarshal error: %w", err)
-	}
-
-	return &status, nil
+func (c *WBClient) GetPaidStorageStatus(ctx context.Context, tokenIdx int, taskID string) (*PaidStorageStatus, error) {
+	return c.GetReportTaskStatus(ctx, tokenIdx, PaidStorageReport, taskID)
 }
 
 // GetPaidStorageDownload скачивает результат задачи по token_idx и task_id
-func (c *WBClient) GetPaidStorageDownload(ctx context.Context, taskID string) ([]map[string]any, error) {
-
-	url := fmt.Sprintf("https://seller-analytics-api.wildberries.ru/api/v1/paid_storage/tasks/%s/download", taskID)
-
-	c.Logger.Info().Msgf("⬇️ Downloading paid_storage report ( task_id=%s)", taskID)
-
-	// увеличенный таймаут, потому что ответ может быть большим
-	localCtx, cancel := context.WithTimeout(ctx, 4*time.Minute)
-	defer cancel()
-
-	body, err := c.doRequest(localCtx, "GET", url, c.Tokens[0], nil)
-	if err != nil {
-		c.Logger.Error().Err(err).Msgf("❌ Download error (task_id=%s)", taskID)
-		return nil, err
-	}
-
+func (c *WBClient) GetPaidStorageDownload(ctx context.Context, tokenIdx int, taskID string) ([]map[string]any, error) {
 	var data []map[string]any
-	if err := json.Unmarshal(body, &data); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON download: %w", err)
+	if err := c.DownloadReportTask(ctx, tokenIdx, PaidStorageReport, taskID, &data); err != nil {
+		return nil, err
 	}
 
 	c.Logger.Info().Msgf("✅ Paid storage report downloaded successfully (records=%d)", len(data))
@@ -4,76 +4,114 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
+// PriceSize — цена конкретного размера товара
+type PriceSize struct {
+	SizeID          int64   `json:"sizeID"`
+	Price           float64 `json:"price"`
+	DiscountedPrice float64 `json:"discountedPrice"`
+	TechSizeName    string  `json:"techSizeName"`
+}
+
 // PriceItem — структура одной записи о товаре из WB API
 type PriceItem struct {
-	ID          int64   `json:"nmId"`
-	Price       float64 `json:"price"`
-	Discount    float64 `json:"discount"`
-	SupplierID  int     `json:"__supplier_id"`
-	SupplierArt string  `json:"supplierArticle,omitempty"`
+	ID          int64       `json:"nmID"`
+	Price       float64     `json:"price"`
+	Discount    float64     `json:"discount"`
+	SupplierID  int         `json:"__supplier_id"`
+	SupplierArt string      `json:"vendorCode,omitempty"`
+	Sizes       []PriceSize `json:"sizes"`
+	TokenIdx    int         `json:"token_idx"`
 	// можно добавить другие поля по необходимости
 }
 
-// GetPrices получает список товаров с ценами постранично по каждому токену
+// GetPrices получает список товаров с ценами постранично по каждому токену.
+// Ошибка страницы логируется, по токену возвращается то, что успели загрузить.
 func (c *WBClient) GetPrices(ctx context.Context, limit, offset int) ([]PriceItem, error) {
 	allPrices := make([]PriceItem, 0)
 
-	for _, token := range c.Tokens {
+	for idx, token := range c.Tokens {
 		if token == "" {
 			continue
 		}
-		tokenTotal := 0
-		pageOffset := offset
+		goods, err := c.fetchTokenPrices(ctx, idx+1, token, limit, offset)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ token_%d: failed to fetch prices", idx+1)
+		}
+		allPrices = append(allPrices, goods...)
+	}
 
-		for {
-			params := map[string]string{
-				"limit":  fmt.Sprintf("%d", limit),
-				"offset": fmt.Sprintf("%d", pageOffset),
-			}
+	return allPrices, nil
+}
 
-			body, err := c.doRequest(ctx, "GET", WBEndpoints.Prices.URL, token, params)
-			if err != nil {
-				c.Logger.Error().Err(err).Msgf("❌ failed to fetch prices ( offset=%d)", pageOffset)
-				break
-			}
+// GetTokenPrices получает все цены одного токена; ошибка любой страницы возвращается
+func (c *WBClient) GetTokenPrices(ctx context.Context, tokenIdx, limit int) ([]PriceItem, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+	if tokenIdx == 0 {
+		tokenIdx = 1
+	}
+	return c.fetchTokenPrices(ctx, tokenIdx, token, limit, 0)
+}
 
-			var resp struct {
-				Data struct {
-					ListGoods []PriceItem `json:"listGoods"`
-				} `json:"data"`
-			}
+// fetchTokenPrices постранично читает цены токена. При ошибке возвращает уже загруженные страницы и ошибку.
+func (c *WBClient) fetchTokenPrices(ctx context.Context, tokenIdx int, token string, limit, offset int) ([]PriceItem, error) {
+	out := make([]PriceItem, 0)
+	pageOffset := offset
 
-			if err := json.Unmarshal(body, &resp); err != nil {
-				c.Logger.Error().Err(err).Msg("unmarshal error in GetPrices response")
-				break
-			}
+	for {
+		params := url.Values{}
+		params.Set("limit", fmt.Sprintf("%d", limit))
+		params.Set("offset", fmt.Sprintf("%d", pageOffset))
 
-			goods := resp.Data.ListGoods
-			if len(goods) == 0 {
-				c.Logger.Info().Msgf("ℹ️ Empty response ( offset=%d) — stopping", pageOffset)
-				break
-			}
+		body, err := c.doRequest(ctx, "GET", WBEndpoints.Prices.URL+"?"+params.Encode(), token, nil)
+		if err != nil {
+			return out, fmt.Errorf("fetch prices (offset=%d): %w", pageOffset, err)
+		}
 
-			allPrices = append(allPrices, goods...)
-			tokenTotal += len(goods)
+		var resp struct {
+			Data struct {
+				ListGoods []PriceItem `json:"listGoods"`
+			} `json:"data"`
+		}
 
-			c.Logger.Info().Msgf("📦 : fetched %d goods (offset=%d)", len(goods), pageOffset)
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return out, fmt.Errorf("unmarshal prices (offset=%d): %w", pageOffset, err)
+		}
 
-			// мягкий rate-limit WB API
-			time.Sleep(600 * time.Millisecond)
+		goods := resp.Data.ListGoods
+		if len(goods) == 0 {
+			c.Logger.Info().Msgf("ℹ️ Empty response ( offset=%d) — stopping", pageOffset)
+			break
+		}
 
-			// если вернулось меньше лимита — значит это последняя страница
-			if len(goods) < limit {
-				break
+		// цена в v2 задаётся на уровне размеров; для товара берём цену первого размера
+		for i := range goods {
+			goods[i].TokenIdx = tokenIdx
+			if goods[i].Price == 0 && len(goods[i].Sizes) > 0 {
+				goods[i].Price = goods[i].Sizes[0].Price
 			}
-			pageOffset += limit
 		}
 
-		c.Logger.Info().Msgf("✅ : total %d price records collected", tokenTotal)
+		out = append(out, goods...)
+
+		c.Logger.Info().Msgf("📦 token_%d: fetched %d goods (offset=%d)", tokenIdx, len(goods), pageOffset)
+
+		// мягкий rate-limit WB API
+		time.Sleep(600 * time.Millisecond)
+
+		// если вернулось меньше лимита — значит это последняя страница
+		if len(goods) < limit {
+			break
+		}
+		pageOffset += limit
 	}
 
-	return allPrices, nil
+	c.Logger.Info().Msgf("✅ token_%d: total %d price records collected", tokenIdx, len(out))
+	return out, nil
 }
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// priceUploadBatch — максимум товаров в одной задаче загрузки цен
+const priceUploadBatch = 1000
+
+// Статусы задачи загрузки цен
+const (
+	PriceTaskProcessing    = 1 // в буфере, обрабатывается
+	PriceTaskDone          = 3 // обработана без ошибок
+	PriceTaskCanceled      = 4 // отменена
+	PriceTaskPartialErrors = 5 // обработана, часть товаров с ошибками
+	PriceTaskAllErrors     = 6 // обработана, все товары с ошибками
+)
+
+// PriceUpdate — новая цена и/или скидка для товара. Nil — значение не меняется.
+type PriceUpdate struct {
+	NmID     int64 `json:"nmID"`
+	Price    *int  `json:"price,omitempty"`
+	Discount *int  `json:"discount,omitempty"`
+}
+
+// PriceUploadTask — задача загрузки цен, созданная в WB
+type PriceUploadTask struct {
+	UploadID      int64   `json:"uploadID"`
+	TokenIdx      int     `json:"token_idx"`
+	AlreadyExists bool    `json:"alreadyExists"`
+	NmIDs         []int64 `json:"nmIDs"`
+}
+
+// PriceUploadStatus — состояние задачи загрузки цен
+type PriceUploadStatus struct {
+	UploadID           int64  `json:"uploadID"`
+	Status             int    `json:"status"`
+	UploadDate         string `json:"uploadDate"`
+	ActivationDate     string `json:"activationDate"`
+	OverAllGoodsNumber int    `json:"overAllGoodsNumber"`
+	SuccessGoodsNumber int    `json:"successGoodsNumber"`
+	InBuffer           bool   `json:"inBuffer"`
+}
+
+// Finished — задача больше не обрабатывается
+func (s PriceUploadStatus) Finished() bool {
+	return !s.InBuffer && s.Status != PriceTaskProcessing && s.Status != 0
+}
+
+// PriceGoodResult — результат обработки одного товара в задаче
+type PriceGoodResult struct {
+	NmID         int64   `json:"nmID"`
+	VendorCode   string  `json:"vendorCode"`
+	SizeID       int64   `json:"sizeID"`
+	TechSizeName string  `json:"techSizeName"`
+	Price        float64 `json:"price"`
+	Discount     int     `json:"discount"`
+	ErrorText    string  `json:"errorText"`
+}
+
+// PriceUploadResult — итог загрузки: статус и ошибки по nmId
+type PriceUploadResult struct {
+	Task   PriceUploadTask   `json:"task"`
+	Status PriceUploadStatus `json:"status"`
+	Errors []PriceGoodResult `json:"errors"`
+}
+
+// pricesEnvelope — общий формат ответа prices API
+type pricesEnvelope struct {
+	Data      json.RawMessage `json:"data"`
+	Error     bool            `json:"error"`
+	ErrorText string          `json:"errorText"`
+}
+
+func (c *WBClient) pricesRequest(ctx context.Context, method, reqURL, token string, payload any) (json.RawMessage, error) {
+	body, err := c.doRequest(ctx, method, reqURL, token, payload)
+	if err != nil {
+		return nil, err
+	}
+	return parsePricesEnvelope(body)
+}
+
+func parsePricesEnvelope(body []byte) (json.RawMessage, error) {
+	var env pricesEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal prices envelope: %w", err)
+	}
+	if env.Error {
+		return nil, errors.New("prices API error: " + env.ErrorText)
+	}
+	return env.Data, nil
+}
+
+// UploadPrices создаёт задачи загрузки цен и скидок пачками по 1000 товаров
+func (c *WBClient) UploadPrices(ctx context.Context, tokenIdx int, items []PriceUpdate) ([]PriceUploadTask, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]PriceUploadTask, 0)
+	for start := 0; start < len(items); start += priceUploadBatch {
+		end := start + priceUploadBatch
+		if end > len(items) {
+			end = len(items)
+		}
+		batch := items[start:end]
+
+		// без retry: повтор после таймаута или 5xx создал бы вторую задачу загрузки
+		body, err := c.doWrite(ctx, http.MethodPost, WBEndpoints.PricesUpload.URL, token, map[string]any{"data": batch})
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to upload prices batch %d..%d", start, end)
+			return tasks, err
+		}
+		data, err := parsePricesEnvelope(body)
+		if err != nil {
+			return tasks, err
+		}
+
+		var resp struct {
+			ID            int64 `json:"id"`
+			AlreadyExists bool  `json:"alreadyExists"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return tasks, fmt.Errorf("%w: upload accepted, unmarshal upload task: %v", ErrOutcomeUnknown, err)
+		}
+
+		nmIDs := make([]int64, 0, len(batch))
+		for _, it := range batch {
+			nmIDs = append(nmIDs, it.NmID)
+		}
+		tasks = append(tasks, PriceUploadTask{
+			UploadID:      resp.ID,
+			TokenIdx:      tokenIdx,
+			AlreadyExists: resp.AlreadyExists,
+			NmIDs:         nmIDs,
+		})
+		c.Logger.Info().Msgf("✅ prices upload task created: id=%d (%d goods)", resp.ID, len(batch))
+
+		// лимит prices API — 10 запросов за 6 секунд
+		time.Sleep(700 * time.Millisecond)
+	}
+
+	return tasks, nil
+}
+
+// GetPriceUploadStatus возвращает состояние задачи: сначала ищет в обработанных, затем в буфере
+func (c *WBClient) GetPriceUploadStatus(ctx context.Context, tokenIdx int, uploadID int64) (*PriceUploadStatus, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{"uploadID": {strconv.FormatInt(uploadID, 10)}}.Encode()
+	for _, src := range []struct {
+		url      string
+		inBuffer bool
+	}{
+		{WBEndpoints.PricesHistoryTasks.URL, false},
+		{WBEndpoints.PricesBufferTasks.URL, true},
+	} {
+		data, err := c.pricesRequest(ctx, http.MethodGet, src.url+"?"+q, token, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 || string(data) == "null" {
+			continue
+		}
+
+		var status PriceUploadStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			return nil, fmt.Errorf("unmarshal upload status: %w", err)
+		}
+		if status.UploadID == 0 {
+			continue
+		}
+		status.InBuffer = src.inBuffer
+		return &status, nil
+	}
+
+	return nil, fmt.Errorf("upload task %d not found", uploadID)
+}
+
+// GetPriceUploadGoods возвращает результат по каждому товару задачи (с текстом ошибки, если она есть)
+func (c *WBClient) GetPriceUploadGoods(ctx context.Context, tokenIdx int, uploadID int64) ([]PriceGoodResult, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	const limit = 1000
+	out := make([]PriceGoodResult, 0)
+	for offset := 0; ; offset += limit {
+		q := url.Values{}
+		q.Set("uploadID", strconv.FormatInt(uploadID, 10))
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("offset", strconv.Itoa(offset))
+
+		data, err := c.pricesRequest(ctx, http.MethodGet, WBEndpoints.PricesHistoryGoods.URL+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return out, err
+		}
+
+		var resp struct {
+			HistoryGoods []PriceGoodResult `json:"historyGoods"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return out, fmt.Errorf("unmarshal upload goods: %w", err)
+		}
+		out = append(out, resp.HistoryGoods...)
+		if len(resp.HistoryGoods) < limit {
+			return out, nil
+		}
+		time.Sleep(700 * time.Millisecond)
+	}
+}
+
+// WaitPriceUpload опрашивает статус задачи, пока она не завершится, и собирает ошибки по nmId
+func (c *WBClient) WaitPriceUpload(ctx context.Context, task PriceUploadTask, pollEvery time.Duration) (*PriceUploadResult, error) {
+	if pollEvery <= 0 {
+		pollEvery = 5 * time.Second
+	}
+
+	for {
+		status, err := c.GetPriceUploadStatus(ctx, task.TokenIdx, task.UploadID)
+		if err != nil {
+			c.Logger.Warn().Err(err).Msgf("⚠️ prices upload %d status not available yet", task.UploadID)
+		} else if status.Finished() {
+			result := &PriceUploadResult{Task: task, Status: *status, Errors: make([]PriceGoodResult, 0)}
+			if status.Status == PriceTaskPartialErrors || status.Status == PriceTaskAllErrors {
+				goods, err := c.GetPriceUploadGoods(ctx, task.TokenIdx, task.UploadID)
+				if err != nil {
+					return result, err
+				}
+				for _, g := range goods {
+					if g.ErrorText != "" {
+						result.Errors = append(result.Errors, g)
+					}
+				}
+			}
+			c.Logger.Info().Msgf("✅ prices upload %d finished: status=%d, errors=%d", task.UploadID, status.Status, len(result.Errors))
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollEvery):
+		}
+	}
+}
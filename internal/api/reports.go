@@ -3,26 +3,72 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
 
+const (
+	// nmHistoryBatch — максимум артикулов в одном запросе истории воронки
+	nmHistoryBatch = 20
+	// nmReportPause — пауза между запросами nm-report (лимит WB — 3 запроса в минуту)
+	nmReportPause = 20 * time.Second
+)
+
 // NMReportItem — структура одного элемента отчёта
 type NMReportItem map[string]any
 
-// GetNMReportHistoryBatched — аналог get_nm_report_history_batched()
-func (c *WBClient) GetNMReportHistoryBatched(ctx context.Context, nmIDs []int, dateFrom, dateTo string) ([]NMReportItem, error) {
-	c.Logger.Info().Msgf("📊 Fetching NM Report history for, period %s..%s", dateFrom, dateTo)
+// NmID возвращает артикул WB карточки отчёта (0, если поля нет)
+func (it NMReportItem) NmID() int64 {
+	v, _ := it["nmID"].(float64)
+	return int64(v)
+}
+
+// SelectedPeriod возвращает заказы и выкупы карточки за запрошенный период (statistics.selectedPeriod)
+func (it NMReportItem) SelectedPeriod() (orders, buyouts int) {
+	stats, _ := it["statistics"].(map[string]any)
+	period, _ := stats["selectedPeriod"].(map[string]any)
+	o, _ := period["ordersCount"].(float64)
+	b, _ := period["buyoutsCount"].(float64)
+	return int(o), int(b)
+}
 
-	baseURL := WBBaseURLs["analytics"] + "/nm-report/history"
-	out := []NMReportItem{}
+// NMHistoryRecord — воронка продаж одного артикула за один день
+type NMHistoryRecord struct {
+	NmID                  int64   `json:"nmID"`
+	VendorCode            string  `json:"vendorCode"`
+	ImtName               string  `json:"imtName"`
+	Date                  string  `json:"dt"`
+	OpenCardCount         int     `json:"openCardCount"`
+	AddToCartCount        int     `json:"addToCartCount"`
+	OrdersCount           int     `json:"ordersCount"`
+	OrdersSumRub          float64 `json:"ordersSumRub"`
+	BuyoutsCount          int     `json:"buyoutsCount"`
+	BuyoutsSumRub         float64 `json:"buyoutsSumRub"`
+	BuyoutPercent         float64 `json:"buyoutPercent"`
+	AddToCartConversion   float64 `json:"addToCartConversion"`
+	CartToOrderConversion float64 `json:"cartToOrderConversion"`
+	TokenIdx              int     `json:"token_idx"`
+}
 
-	chunks := chunkIntSlice(nmIDs, 20)
-	for _, batch := range chunks {
-		select {
-		case <-ctx.Done():
-			return out, ctx.Err()
-		default:
+// GetNMReportHistory возвращает воронку по дням для артикулов nmIDs за [dateFrom, dateTo] (YYYY-MM-DD, не старше недели).
+// Запросы идут пачками по 20 артикулов; ошибка пачки не прерывает остальные и возвращается в конце.
+func (c *WBClient) GetNMReportHistory(ctx context.Context, tokenIdx int, nmIDs []int64, dateFrom, dateTo string) ([]NMHistoryRecord, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger.Info().Msgf("📊 Fetching NM Report history (token_%d, nmIDs=%d, period %s..%s)", tokenIdx, len(nmIDs), dateFrom, dateTo)
+
+	out := []NMHistoryRecord{}
+	var lastErr error
+	for i, batch := range chunkInt64Slice(nmIDs, nmHistoryBatch) {
+		if i > 0 {
+			if err := sleepCtx(ctx, nmReportPause); err != nil {
+				return out, err
+			}
 		}
 
 		payload := map[string]any{
@@ -35,94 +81,155 @@ func (c *WBClient) GetNMReportHistoryBatched(ctx context.Context, nmIDs []int, d
 			"nmIDs":            batch,
 		}
 
-		body, err := c.doRequest(ctx, http.MethodPost, baseURL, c.Tokens[0], payload)
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.NMReportHistory.URL, token, payload)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("nm-report/history error for ")
+			c.Logger.Error().Err(err).Msgf("❌ nm-report/history error (token_%d, batch %d)", tokenIdx, i+1)
+			lastErr = err
 			continue
 		}
 
 		var resp struct {
-			Data []NMReportItem `json:"data"`
+			Data []struct {
+				NmID       int64             `json:"nmID"`
+				VendorCode string            `json:"vendorCode"`
+				ImtName    string            `json:"imtName"`
+				History    []NMHistoryRecord `json:"history"`
+			} `json:"data"`
 		}
 		if err := json.Unmarshal(body, &resp); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal nm history error")
+			lastErr = fmt.Errorf("unmarshal nm-report history: %w", err)
 			continue
 		}
 
-		out = append(out, resp.Data...)
-		time.Sleep(20 * time.Second)
+		for _, card := range resp.Data {
+			for _, day := range card.History {
+				day.NmID, day.VendorCode, day.ImtName, day.TokenIdx = card.NmID, card.VendorCode, card.ImtName, tokenIdx
+				out = append(out, day)
+			}
+		}
 	}
 
-	return out, nil
+	return out, lastErr
 }
 
-// GetNMReportDetailYesterday — аналог get_nm_report_detail_yesterday()
-func (c *WBClient) GetNMReportDetailYesterday(ctx context.Context, begin, end string) ([]NMReportItem, error) {
-	c.Logger.Info().Msgf("📄 Fetching NM Report detail for %s..%s", begin, end)
+// ErrNMDetailIncomplete — лимит ошибок исчерпан, детальный отчёт выгружен не полностью
+var ErrNMDetailIncomplete = errors.New("nm-report detail incomplete: error budget exhausted")
 
-	baseURL := WBBaseURLs["analytics"] + "/nm-report/detail"
-	cards := []NMReportItem{}
+// GetNMReportDetailPage возвращает одну страницу детального отчёта по воронке
+// (begin/end — "YYYY-MM-DD HH:MM:SS") и признак наличия следующей страницы
+func (c *WBClient) GetNMReportDetailPage(ctx context.Context, tokenIdx int, begin, end string, page int) ([]NMReportItem, bool, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, false, err
+	}
 
-	page := 1
-	for {
-		select {
-		case <-ctx.Done():
-			return cards, ctx.Err()
-		default:
-		}
+	// сортировка по артикулу: порядок по метрикам (сумме заказов и т. п.) меняется между запросами,
+	// и карточки на границе страниц пропускаются или дублируются
+	payload := map[string]any{
+		"timezone": "Europe/Moscow",
+		"period": map[string]string{
+			"begin": begin,
+			"end":   end,
+		},
+		"orderBy": map[string]string{
+			"field": "nmID",
+			"mode":  "asc",
+		},
+		"page": page,
+	}
 
-		payload := map[string]any{
-			"timezone": "Europe/Moscow",
-			"period": map[string]string{
-				"begin": begin,
-				"end":   end,
-			},
-			"orderBy": map[string]string{
-				"field": "ordersSumRub",
-				"mode":  "asc",
-			},
-			"page": page,
-		}
+	body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.NMReportDetail.URL, token, payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("nm-report/detail page %d: %w", page, err)
+	}
+
+	var resp struct {
+		Data struct {
+			Cards      []NMReportItem `json:"cards"`
+			IsNextPage bool           `json:"isNextPage"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, false, fmt.Errorf("unmarshal nm-report detail page %d: %w", page, err)
+	}
+
+	return resp.Data.Cards, resp.Data.IsNextPage && len(resp.Data.Cards) > 0, nil
+}
 
-		body, err := c.doRequest(ctx, http.MethodPost, baseURL, c.Tokens[0], payload)
+// WalkNMReportDetail листает детальный отчёт, начиная со страницы page, и вызывает onPage после каждой
+// загруженной страницы — там удобно сохранять прогресс. Ошибочная страница запрашивается повторно
+// с нарастающей паузой; после maxErrors ошибок возвращается ErrNMDetailIncomplete.
+func (c *WBClient) WalkNMReportDetail(ctx context.Context, tokenIdx int, begin, end string, page, maxErrors int,
+	onPage func(page int, cards []NMReportItem) error) error {
+	if page < 1 {
+		page = 1
+	}
+	c.Logger.Info().Msgf("📄 Fetching NM Report detail (token_%d) for %s..%s from page %d", tokenIdx, begin, end, page)
+
+	errCount := 0
+	backoff := nmReportPause
+	for {
+		cards, next, err := c.GetNMReportDetailPage(ctx, tokenIdx, begin, end, page)
 		if err != nil {
-			c.Logger.Error().Err(err).Msgf("nm-report/detail error page=%d", page)
-			time.Sleep(20 * time.Second)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			errCount++
+			if errCount > maxErrors {
+				return fmt.Errorf("%w: %v", ErrNMDetailIncomplete, err)
+			}
+			c.Logger.Warn().Err(err).Msgf("⚠️ nm-report/detail page %d failed (token_%d, error %d/%d), retry in %s",
+				page, tokenIdx, errCount, maxErrors, backoff)
+			if err := sleepCtx(ctx, backoff); err != nil {
+				return err
+			}
+			if backoff < 2*time.Minute {
+				backoff *= 2
+			}
 			continue
 		}
+		backoff = nmReportPause
 
-		var resp struct {
-			Data struct {
-				Cards      []NMReportItem `json:"cards"`
-				IsNextPage bool           `json:"isNextPage"`
-			} `json:"data"`
+		if err := onPage(page, cards); err != nil {
+			return err
 		}
-		if err := json.Unmarshal(body, &resp); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal nm detail error")
-			break
-		}
-
-		if len(resp.Data.Cards) == 0 {
-			break
+		if !next {
+			return nil
 		}
 
-		cards = append(cards, resp.Data.Cards...)
 		page++
-		time.Sleep(20 * time.Second)
-		if !resp.Data.IsNextPage {
-			break
+		if err := sleepCtx(ctx, nmReportPause); err != nil {
+			return err
 		}
 	}
+}
+
+// GetNMReportDetail возвращает все карточки детального отчёта за период
+func (c *WBClient) GetNMReportDetail(ctx context.Context, tokenIdx int, begin, end string) ([]NMReportItem, error) {
+	cards := []NMReportItem{}
+	err := c.WalkNMReportDetail(ctx, tokenIdx, begin, end, 1, 5, func(_ int, page []NMReportItem) error {
+		cards = append(cards, page...)
+		return nil
+	})
+	return cards, err
+}
 
-	return cards, nil
+// sleepCtx ждёт d или отмены контекста
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
 }
 
 // Helpers
-func chunkIntSlice(s []int, n int) [][]int {
+func chunkInt64Slice(s []int64, n int) [][]int64 {
 	if len(s) == 0 {
 		return nil
 	}
-	var chunks [][]int
+	var chunks [][]int64
 	for i := 0; i < len(s); i += n {
 		end := i + n
 		if end > len(s) {
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// NMGroupFilter — фильтр групповых отчётов по воронке (пусто — все группы)
+type NMGroupFilter struct {
+	SubjectIDs []int
+	Brands     []string
+	TagIDs     []int
+}
+
+func (f NMGroupFilter) payload() map[string]any {
+	p := map[string]any{"timezone": "Europe/Moscow"}
+	if len(f.SubjectIDs) > 0 {
+		p["objectIDs"] = f.SubjectIDs
+	}
+	if len(f.Brands) > 0 {
+		p["brandNames"] = f.Brands
+	}
+	if len(f.TagIDs) > 0 {
+		p["tagIDs"] = f.TagIDs
+	}
+	return p
+}
+
+// NMGroupStats — воронка группы карточек (предмет + бренд + ярлык) за период или за день
+type NMGroupStats struct {
+	SubjectID   int    `json:"subjectId"`
+	SubjectName string `json:"subjectName"`
+	BrandName   string `json:"brandName"`
+	TagID       int    `json:"tagId"`
+	TagName     string `json:"tagName"`
+
+	Date       string `json:"dt,omitempty"` // день для истории, пусто — итог за период
+	PeriodFrom string `json:"period_from"`
+	PeriodTo   string `json:"period_to"`
+
+	OpenCardCount  int     `json:"openCardCount"`
+	AddToCartCount int     `json:"addToCartCount"`
+	OrdersCount    int     `json:"ordersCount"`
+	OrdersSumRub   float64 `json:"ordersSumRub"`
+	BuyoutsCount   int     `json:"buyoutsCount"`
+	BuyoutsSumRub  float64 `json:"buyoutsSumRub"`
+	CancelCount    int     `json:"cancelCount"`
+	CancelSumRub   float64 `json:"cancelSumRub"`
+
+	TokenIdx int `json:"token_idx"`
+}
+
+// nmGroupRaw — группа в ответах grouped и grouped/history
+type nmGroupRaw struct {
+	Object struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"object"`
+	BrandName string `json:"brandName"`
+	Tag       struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"tag"`
+}
+
+func (g nmGroupRaw) stats(tokenIdx int, from, to string) NMGroupStats {
+	return NMGroupStats{
+		SubjectID:   g.Object.ID,
+		SubjectName: g.Object.Name,
+		BrandName:   g.BrandName,
+		TagID:       g.Tag.ID,
+		TagName:     g.Tag.Name,
+		PeriodFrom:  from,
+		PeriodTo:    to,
+		TokenIdx:    tokenIdx,
+	}
+}
+
+// GetNMReportGrouped возвращает итоги воронки по группам за период (dateFrom/dateTo — YYYY-MM-DD)
+func (c *WBClient) GetNMReportGrouped(ctx context.Context, tokenIdx int, filter NMGroupFilter, dateFrom, dateTo string) ([]NMGroupStats, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []NMGroupStats{}
+	for page := 1; ; page++ {
+		if page > 1 {
+			if err := sleepCtx(ctx, nmReportPause); err != nil {
+				return out, err
+			}
+		}
+
+		payload := filter.payload()
+		payload["period"] = map[string]string{"begin": dateFrom + " 00:00:00", "end": dateTo + " 23:59:59"}
+		payload["orderBy"] = map[string]string{"field": "ordersSumRub", "mode": "desc"}
+		payload["page"] = page
+
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.NMReportGrouped.URL, token, payload)
+		if err != nil {
+			return out, fmt.Errorf("nm-report/grouped page %d: %w", page, err)
+		}
+
+		var resp struct {
+			Data struct {
+				Groups []struct {
+					nmGroupRaw
+					Statistics struct {
+						SelectedPeriod NMGroupStats `json:"selectedPeriod"`
+					} `json:"statistics"`
+				} `json:"groups"`
+				IsNextPage bool `json:"isNextPage"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return out, fmt.Errorf("unmarshal nm-report grouped: %w", err)
+		}
+
+		for _, g := range resp.Data.Groups {
+			st := g.stats(tokenIdx, dateFrom, dateTo)
+			sp := g.Statistics.SelectedPeriod
+			st.OpenCardCount, st.AddToCartCount, st.OrdersCount, st.OrdersSumRub = sp.OpenCardCount, sp.AddToCartCount, sp.OrdersCount, sp.OrdersSumRub
+			st.BuyoutsCount, st.BuyoutsSumRub, st.CancelCount, st.CancelSumRub = sp.BuyoutsCount, sp.BuyoutsSumRub, sp.CancelCount, sp.CancelSumRub
+			out = append(out, st)
+		}
+		if !resp.Data.IsNextPage || len(resp.Data.Groups) == 0 {
+			return out, nil
+		}
+	}
+}
+
+// GetNMReportGroupedHistory возвращает воронку групп по дням за [dateFrom, dateTo] (не старше недели)
+func (c *WBClient) GetNMReportGroupedHistory(ctx context.Context, tokenIdx int, filter NMGroupFilter, dateFrom, dateTo string) ([]NMGroupStats, error) {
+	token, err := c.tokenByIdx(tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := filter.payload()
+	payload["period"] = map[string]string{"begin": dateFrom, "end": dateTo}
+	payload["aggregationLevel"] = "day"
+
+	body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.NMReportGroupedHistory.URL, token, payload)
+	if err != nil {
+		return nil, fmt.Errorf("nm-report/grouped/history: %w", err)
+	}
+
+	var resp struct {
+		Data []struct {
+			nmGroupRaw
+			History []NMGroupStats `json:"history"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal nm-report grouped history: %w", err)
+	}
+
+	out := []NMGroupStats{}
+	for _, g := range resp.Data {
+		for _, day := range g.History {
+			st := g.stats(tokenIdx, dateFrom, dateTo)
+			st.Date = day.Date
+			st.OpenCardCount, st.AddToCartCount, st.OrdersCount, st.OrdersSumRub = day.OpenCardCount, day.AddToCartCount, day.OrdersCount, day.OrdersSumRub
+			st.BuyoutsCount, st.BuyoutsSumRub = day.BuyoutsCount, day.BuyoutsSumRub
+			out = append(out, st)
+		}
+	}
+
+	c.Logger.Info().Msgf("📊 nm-report grouped history (token_%d, %s..%s): %d records", tokenIdx, dateFrom, dateTo, len(out))
+	return out, nil
+}
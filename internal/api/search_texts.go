@@ -1,110 +1,117 @@
 package api
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"time"
 )
 
-// PostSearchTexts выполняет POST-запрос к endpoint'у search-texts.
-// Возвращает JSON как map[string]interface{}.
-// Если сервер вернул не-200 — возвращаем map{"error": {"status": code, "message": body}} (без error),
-// чтобы поведение было похоже на Python-реализацию.
-// Если токен == "" — используется первый токен в c.Tokens.
-// NOTE: Проверь правильный path для своего окружения; здесь примерный путь.
-func (c *WBClient) PostSearchTexts(ctx context.Context, payload map[string]interface{}, token string) (map[string]interface{}, error) {
-	// выбор токена
-	useToken := token
-	if useToken == "" {
-		if len(c.Tokens) == 0 {
-			return nil, fmt.Errorf("no wb tokens configured")
-		}
-		useToken = c.Tokens[0]
-	}
+// Метрики, по которым WB отбирает топ поисковых запросов артикула
+const (
+	SearchTopOpenCard    = "openCard"
+	SearchTopAddToCart   = "addToCart"
+	SearchTopOpenToCart  = "openToCart"
+	SearchTopOrders      = "orders"
+	SearchTopCartToOrder = "cartToOrder"
+)
 
-	// endpoint — проверьте ваш фактический путь: тут примерный
-	// (в Python у тебя ENDPOINTS['search_texts'])
-	endpoint := WBBaseURLs["analytics"] + "/nm-report/search-texts"
+// searchTextsBatch — максимум артикулов в одном запросе отчёта по поисковым запросам
+const searchTextsBatch = 50
 
-	// marshal payload
-	bodyBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("marshal payload: %w", err)
-	}
+// SearchTextsQuery — параметры отчёта по поисковым запросам
+type SearchTextsQuery struct {
+	NmIDs      []int64
+	From       string // YYYY-MM-DD
+	To         string // YYYY-MM-DD
+	TopOrderBy string // SearchTop*, по умолчанию SearchTopOpenCard
+	Limit      int    // запросов на артикул, по умолчанию 30
+}
 
-	// настроим client с увеличенным таймаутом (по аналогии с sock_read=240)
-	reqTimeout := c.ReadTimeout
-	if reqTimeout == 0 {
-		reqTimeout = 240 * time.Second
-	}
+// SearchMetric — значение метрики за период и изменение к прошлому периоду
+type SearchMetric struct {
+	Current  float64 `json:"current"`
+	Dynamics float64 `json:"dynamics"`
+}
 
-	httpClient := &http.Client{
-		Timeout: reqTimeout,
-	}
+// SearchText — поисковый запрос, по которому покупатели находили артикул
+type SearchText struct {
+	Text           string       `json:"text"`
+	NmID           int64        `json:"nmId"`
+	VendorCode     string       `json:"vendorCode"`
+	SubjectName    string       `json:"subjectName"`
+	BrandName      string       `json:"brandName"`
+	Frequency      SearchMetric `json:"frequency"`
+	WeekFrequency  int          `json:"weekFrequency"`
+	AvgPosition    SearchMetric `json:"avgPosition"`
+	MedianPosition SearchMetric `json:"medianPosition"`
+	OpenCard       SearchMetric `json:"openCard"`
+	AddToCart      SearchMetric `json:"addToCart"`
+	OpenToCart     SearchMetric `json:"openToCart"` // конверсия в корзину, %
+	Orders         SearchMetric `json:"orders"`
+	CartToOrder    SearchMetric `json:"cartToOrder"` // конверсия в заказ, %
+	Visibility     SearchMetric `json:"visibility"`
 
-	// создаём контекст с таймаутом на случай, если caller дал общий ctx без deadline
-	ctxReq, cancel := context.WithTimeout(ctx, reqTimeout)
-	defer cancel()
+	PeriodFrom string `json:"period_from"`
+	PeriodTo   string `json:"period_to"`
+	TokenIdx   int    `json:"token_idx"`
+}
 
-	req, err := http.NewRequestWithContext(ctxReq, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
+// GetSearchTexts возвращает топ поисковых запросов по артикулам за период.
+// Артикулы запрашиваются пачками по 50 с паузой между запросами (лимит WB — 3 запроса в минуту).
+func (c *WBClient) GetSearchTexts(ctx context.Context, tokenIdx int, q SearchTextsQuery) ([]SearchText, error) {
+	token, err := c.tokenByIdx(tokenIdx)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", useToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		// таймаут/сетевая ошибка — возвращаем её как ошибку (как в Python: Timeout -> exception)
-		return nil, fmt.Errorf("http request error: %w", err)
+	if q.From == "" || q.To == "" {
+		return nil, fmt.Errorf("search-texts: period is required")
 	}
-	defer resp.Body.Close()
-
-	respBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read body error: %w", err)
+	if q.TopOrderBy == "" {
+		q.TopOrderBy = SearchTopOpenCard
+	}
+	if q.Limit <= 0 {
+		q.Limit = 30
 	}
 
-	// Если статус != 200 — возвращаем map с ключом error (поведение Python)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		msg := string(respBytes)
-		out := map[string]interface{}{
-			"error": map[string]interface{}{
-				"status":  resp.StatusCode,
-				"message": msg,
-			},
+	out := []SearchText{}
+	for i, batch := range chunkInt64Slice(q.NmIDs, searchTextsBatch) {
+		if i > 0 {
+			if err := sleepCtx(ctx, nmReportPause); err != nil {
+				return out, err
+			}
 		}
-		return out, nil
-	}
 
-	// Парсим JSON минимально: если это object -> возвращаем его как map[string]interface{}
-	// если это array -> упакуем в {"data": [...]}
-	var parsed any
-	if err := json.Unmarshal(respBytes, &parsed); err != nil {
-		// Попытка fallback: вернуть текст ошибки в структуре
-		out := map[string]interface{}{
-			"error": map[string]interface{}{
-				"status":  "parse_error",
-				"message": fmt.Sprintf("failed to parse json response: %v; raw: %s", err, string(respBytes)),
-			},
+		payload := map[string]any{
+			"currentPeriod":          map[string]string{"start": q.From, "end": q.To},
+			"nmIds":                  batch,
+			"topOrderBy":             q.TopOrderBy,
+			"includeSubstitutedSKUs": true,
+			"includeSearchTexts":     true,
+			"orderBy":                map[string]string{"field": "avgPosition", "mode": "asc"},
+			"limit":                  q.Limit,
 		}
-		return out, nil
-	}
 
-	switch v := parsed.(type) {
-	case map[string]interface{}:
-		return v, nil
-	default:
-		// например []any -> упакуем в поле data, чтобы результат всегда map[string]interface{}
-		out := map[string]interface{}{
-			"data": v,
+		body, err := c.doRequest(ctx, http.MethodPost, WBEndpoints.SearchTexts.URL, token, payload)
+		if err != nil {
+			return out, fmt.Errorf("search-texts (token_%d, batch %d): %w", tokenIdx, i+1, err)
+		}
+
+		var resp struct {
+			Data struct {
+				Items []SearchText `json:"items"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return out, fmt.Errorf("unmarshal search-texts: %w", err)
+		}
+
+		for _, it := range resp.Data.Items {
+			it.PeriodFrom, it.PeriodTo, it.TokenIdx = q.From, q.To, tokenIdx
+			out = append(out, it)
 		}
-		return out, nil
 	}
+
+	c.Logger.Info().Msgf("🔎 search-texts (token_%d, %s..%s): %d queries for %d nmIDs", tokenIdx, q.From, q.To, len(out), len(q.NmIDs))
+	return out, nil
 }
@@ -4,85 +4,108 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"time"
 )
 
 type WBRecord map[string]any
 
-// GetOrders — получение заказов
+// Float возвращает числовое поле записи (JSON-числа приходят как float64); 0, если поля нет
+func (r WBRecord) Float(key string) float64 {
+	v, _ := r[key].(float64)
+	return v
+}
+
+// Int64 возвращает целочисленное поле записи, например nmId
+func (r WBRecord) Int64(key string) int64 {
+	return int64(r.Float(key))
+}
+
+// String возвращает строковое поле записи
+func (r WBRecord) String(key string) string {
+	v, _ := r[key].(string)
+	return v
+}
+
+// Bool возвращает логическое поле записи, например isCancel
+func (r WBRecord) Bool(key string) bool {
+	v, _ := r[key].(bool)
+	return v
+}
+
+// TokenIdx возвращает номер токена, которым получена запись (token_idx, с 1); 0 — неизвестен
+func (r WBRecord) TokenIdx() int {
+	switch v := r["token_idx"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// GetOrders — получение заказов по всем токенам.
+// Ошибка любого токена возвращается: по неполным заказам аналитика дала бы ложные выводы.
 func (c *WBClient) GetOrders(ctx context.Context, dateFrom, dateTo string) ([]WBRecord, error) {
 	all := []WBRecord{}
-	urlTemplate := fmt.Sprintf("https://statistics-api.wildberries.ru/api/v1/supplier/orders?dateFrom=%s", dateFrom)
+	extra := url.Values{}
 	if dateTo != "" {
-		urlTemplate += "&dateTo=" + dateTo
+		extra.Set("dateTo", dateTo)
 	}
 
-	// := c.SupplierIDs[i]
-	url := urlTemplate
-	c.Logger.Info().Msgf("📦 Fetching orders for  from=%s to=%s", dateFrom, dateTo)
-
-	body, err := c.doRequest(ctx, "GET", url, c.Tokens[0], nil)
-	if err != nil {
-		c.Logger.Error().Err(err).Msgf("Failed to get orders for ")
-		return nil, err
-	}
+	for idx, token := range c.Tokens {
+		if token == "" {
+			continue
+		}
+		c.Logger.Info().Msgf("📦 Fetching orders (token_%d) from=%s to=%s", idx+1, dateFrom, dateTo)
 
-	var data []WBRecord
-	if err := json.Unmarshal(body, &data); err != nil {
-		c.Logger.Error().Err(err).Msg("unmarshal orders error")
-		return nil, err
+		data, err := c.getStatistics(ctx, "orders", WBEndpoints.Orders.URL, dateFrom, extra, idx+1, token)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("Failed to get orders (token_%d)", idx+1)
+			return nil, err
+		}
+		all = append(all, data...)
 	}
 
-	all = append(all, data...)
-
 	return all, nil
 }
 
-// GetSales — получение продаж
+// GetSales — получение продаж и возвратов по всем токенам; ошибка любого токена возвращается
 func (c *WBClient) GetSales(ctx context.Context, dateFrom string) ([]WBRecord, error) {
 	all := []WBRecord{}
-	urlTemplate := fmt.Sprintf("https://statistics-api.wildberries.ru/api/v1/supplier/sales?dateFrom=%s", dateFrom)
-
-	for _, token := range c.Tokens {
-		c.Logger.Info().Msgf("💰 Fetching sales for  from=%s", dateFrom)
 
-		body, err := c.doRequest(ctx, "GET", urlTemplate, token, nil)
-		if err != nil {
-			c.Logger.Error().Err(err).Msgf("Failed to get sales for ")
+	for idx, token := range c.Tokens {
+		if token == "" {
 			continue
 		}
+		c.Logger.Info().Msgf("💰 Fetching sales (token_%d) from=%s", idx+1, dateFrom)
 
-		var data []WBRecord
-		if err := json.Unmarshal(body, &data); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal sales error")
-			continue
+		data, err := c.getStatistics(ctx, "sales", WBEndpoints.Sales.URL, dateFrom, nil, idx+1, token)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("Failed to get sales (token_%d)", idx+1)
+			return nil, err
 		}
-
 		all = append(all, data...)
 	}
 
 	return all, nil
 }
 
-// GetStocks — получение остатков
+// GetStocks — получение остатков по всем токенам; ошибка любого токена возвращается
 func (c *WBClient) GetStocks(ctx context.Context, dateFrom string) ([]WBRecord, error) {
 	all := []WBRecord{}
-	urlTemplate := fmt.Sprintf("https://statistics-api.wildberries.ru/api/v1/supplier/stocks?dateFrom=%s", dateFrom)
 
-	for _, token := range c.Tokens {
-		c.Logger.Info().Msgf("📦 Fetching stocks for  from=%s", dateFrom)
-
-		body, err := c.doRequest(ctx, "GET", urlTemplate, token, nil)
-		if err != nil {
-			c.Logger.Error().Err(err).Msgf("Failed to get stocks for ")
+	for idx, token := range c.Tokens {
+		if token == "" {
 			continue
 		}
+		c.Logger.Info().Msgf("📦 Fetching stocks (token_%d) from=%s", idx+1, dateFrom)
 
-		var data []WBRecord
-		if err := json.Unmarshal(body, &data); err != nil {
-			c.Logger.Error().Err(err).Msg("unmarshal stocks error")
-			continue
+		data, err := c.getStatistics(ctx, "stocks", WBEndpoints.Stocks.URL, dateFrom, nil, idx+1, token)
+		if err != nil {
+			c.Logger.Error().Err(err).Msgf("Failed to get stocks (token_%d)", idx+1)
+			return nil, err
 		}
-
 		all = append(all, data...)
 	}
 
@@ -114,3 +137,65 @@ func (c *WBClient) GetIncomes(ctx context.Context, dateFrom string) ([]WBRecord,
 
 	return all, nil
 }
+
+const (
+	// statisticsPageLimit — WB Statistics API отдаёт не больше ~80 000 строк за запрос;
+	// полная страница означает, что есть продолжение с lastChangeDate последней строки
+	statisticsPageLimit = 80000
+	// statisticsPause — лимит Statistics API — 1 запрос в минуту на токен
+	statisticsPause = time.Minute
+)
+
+// getStatistics загружает все страницы метода Statistics API по одному токену.
+// Каждой записи добавляется token_idx; строки на стыке страниц (одинаковый lastChangeDate) не дублируются.
+func (c *WBClient) getStatistics(ctx context.Context, name, endpoint, dateFrom string, extra url.Values, tokenIdx int, token string) ([]WBRecord, error) {
+	all := []WBRecord{}
+	seen := map[string]bool{}
+
+	for page := 1; ; page++ {
+		q := url.Values{}
+		for k, v := range extra {
+			q[k] = v
+		}
+		q.Set("dateFrom", dateFrom)
+
+		body, err := c.doRequest(ctx, "GET", endpoint+"?"+q.Encode(), token, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s (token_%d, page %d): %w", name, tokenIdx, page, err)
+		}
+
+		var data []WBRecord
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal %s (token_%d): %w", name, tokenIdx, err)
+		}
+
+		for _, r := range data {
+			key := statisticsKey(r)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			r["token_idx"] = tokenIdx
+			all = append(all, r)
+		}
+
+		if len(data) < statisticsPageLimit {
+			return all, nil
+		}
+		next := data[len(data)-1].String("lastChangeDate")
+		if next == "" || next == dateFrom {
+			return nil, fmt.Errorf("%s (token_%d): cannot continue paging after %s", name, tokenIdx, dateFrom)
+		}
+		dateFrom = next
+
+		if err := sleepCtx(ctx, statisticsPause); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// statisticsKey — ключ строки Statistics API для дедупликации на стыке страниц
+func statisticsKey(r WBRecord) string {
+	return r.String("srid") + "|" + r.String("saleID") + "|" + r.String("barcode") + "|" +
+		r.String("warehouseName") + "|" + r.String("lastChangeDate")
+}
@@ -0,0 +1,106 @@
+package api
+
+import "math"
+
+// BoxTariff — коробный тариф склада. Строковые поля — как их отдаёт WB ("48", "1,25", "-"),
+// числовые разбираются из них методом Parse; nil — значение не указано.
+type BoxTariff struct {
+	BoxDeliveryBase                string `json:"boxDeliveryBase"`
+	BoxDeliveryCoefExpr            string `json:"boxDeliveryCoefExpr"`
+	BoxDeliveryLiter               string `json:"boxDeliveryLiter"`
+	BoxDeliveryMarketplaceBase     string `json:"boxDeliveryMarketplaceBase"`
+	BoxDeliveryMarketplaceCoefExpr string `json:"boxDeliveryMarketplaceCoefExpr"`
+	BoxDeliveryMarketplaceLiter    string `json:"boxDeliveryMarketplaceLiter"`
+	BoxStorageBase                 string `json:"boxStorageBase"`
+	BoxStorageCoefExpr             string `json:"boxStorageCoefExpr"`
+	BoxStorageLiter                string `json:"boxStorageLiter"`
+	GeoName                        string `json:"geoName"`
+	WarehouseName                  string `json:"warehouseName"`
+
+	DeliveryBase               *float64 `json:"deliveryBase"`            // логистика первого литра, ₽
+	DeliveryLiter              *float64 `json:"deliveryLiter"`           // логистика каждого следующего литра, ₽
+	DeliveryCoefPct            *float64 `json:"deliveryCoefPct"`         // коэффициент логистики склада, %
+	DeliveryMarketplaceBase    *float64 `json:"deliveryMarketplaceBase"` // логистика FBS первого литра, ₽
+	DeliveryMarketplaceLiter   *float64 `json:"deliveryMarketplaceLiter"`
+	DeliveryMarketplaceCoefPct *float64 `json:"deliveryMarketplaceCoefPct"`
+	StorageBase                *float64 `json:"storageBase"`  // хранение первого литра в день, ₽
+	StorageLiter               *float64 `json:"storageLiter"` // хранение каждого следующего литра в день, ₽
+	StorageCoefPct             *float64 `json:"storageCoefPct"`
+}
+
+// Parse заполняет числовые поля из строковых
+func (t *BoxTariff) Parse() {
+	t.DeliveryBase = parseTariff(t.BoxDeliveryBase)
+	t.DeliveryLiter = parseTariff(t.BoxDeliveryLiter)
+	t.DeliveryCoefPct = parseTariff(t.BoxDeliveryCoefExpr)
+	t.DeliveryMarketplaceBase = parseTariff(t.BoxDeliveryMarketplaceBase)
+	t.DeliveryMarketplaceLiter = parseTariff(t.BoxDeliveryMarketplaceLiter)
+	t.DeliveryMarketplaceCoefPct = parseTariff(t.BoxDeliveryMarketplaceCoefExpr)
+	t.StorageBase = parseTariff(t.BoxStorageBase)
+	t.StorageLiter = parseTariff(t.BoxStorageLiter)
+	t.StorageCoefPct = parseTariff(t.BoxStorageCoefExpr)
+}
+
+// DeliveryCost — логистика одной единицы объёмом liters до покупателя; false — тариф склада не указан
+func (t BoxTariff) DeliveryCost(liters float64) (float64, bool) {
+	return perLiterCost(t.DeliveryBase, t.DeliveryLiter, liters)
+}
+
+// StorageCostPerDay — хранение одной единицы объёмом liters в день; false — тариф склада не указан
+func (t BoxTariff) StorageCostPerDay(liters float64) (float64, bool) {
+	return perLiterCost(t.StorageBase, t.StorageLiter, liters)
+}
+
+// PalletTariff — паллетный тариф склада; числовые поля разбираются методом Parse, nil — не указано
+type PalletTariff struct {
+	PalletDeliveryExpr       string `json:"palletDeliveryExpr"`
+	PalletDeliveryValueBase  string `json:"palletDeliveryValueBase"`
+	PalletDeliveryValueLiter string `json:"palletDeliveryValueLiter"`
+	PalletStorageExpr        string `json:"palletStorageExpr"`
+	PalletStorageValueExpr   string `json:"palletStorageValueExpr"`
+	WarehouseName            string `json:"warehouseName"`
+
+	DeliveryBase    *float64 `json:"deliveryBase"`    // логистика первого литра, ₽
+	DeliveryLiter   *float64 `json:"deliveryLiter"`   // логистика каждого следующего литра, ₽
+	DeliveryCoefPct *float64 `json:"deliveryCoefPct"` // коэффициент логистики склада, %
+	StoragePallet   *float64 `json:"storagePallet"`   // хранение одной паллеты в день, ₽
+	StorageCoefPct  *float64 `json:"storageCoefPct"`
+}
+
+// Parse заполняет числовые поля из строковых
+func (t *PalletTariff) Parse() {
+	t.DeliveryBase = parseTariff(t.PalletDeliveryValueBase)
+	t.DeliveryLiter = parseTariff(t.PalletDeliveryValueLiter)
+	t.DeliveryCoefPct = parseTariff(t.PalletDeliveryExpr)
+	t.StoragePallet = parseTariff(t.PalletStorageValueExpr)
+	t.StorageCoefPct = parseTariff(t.PalletStorageExpr)
+}
+
+// DeliveryCost — логистика одной единицы объёмом liters; false — тариф склада не указан
+func (t PalletTariff) DeliveryCost(liters float64) (float64, bool) {
+	return perLiterCost(t.DeliveryBase, t.DeliveryLiter, liters)
+}
+
+// parseTariff разбирает значение тарифа; "-" и пустая строка — nil
+func parseTariff(s string) *float64 {
+	v, ok := ParseWBNumber(s)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// perLiterCost — цена первого литра плюс цена каждого следующего (объём до литра считается как литр)
+func perLiterCost(base, liter *float64, liters float64) (float64, bool) {
+	if base == nil {
+		return 0, false
+	}
+	cost := *base
+	if extra := liters - 1; extra > 0 {
+		if liter == nil {
+			return 0, false
+		}
+		cost += extra * *liter
+	}
+	return math.Round(cost*100) / 100, true
+}
@@ -0,0 +1,84 @@
+package api
+
+import "testing"
+
+func TestParseTariff(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *float64
+	}{
+		{"-", nil},
+		{"", nil},
+		{"1,25", ptr(1.25)},
+		{" 48 ", ptr(48)},
+		{"0", ptr(0)},
+	}
+
+	for _, tt := range tests {
+		got := parseTariff(tt.in)
+		switch {
+		case tt.want == nil && got != nil:
+			t.Errorf("parseTariff(%q) = %v, want nil", tt.in, *got)
+		case tt.want != nil && (got == nil || *got != *tt.want):
+			t.Errorf("parseTariff(%q) = %v, want %v", tt.in, got, *tt.want)
+		}
+	}
+}
+
+func TestPerLiterCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		base, liter *float64
+		liters      float64
+		want        float64
+		wantOK      bool
+	}{
+		{name: "no base tariff", liter: ptr(11.2), liters: 3},
+		{name: "under a liter costs the first liter", base: ptr(48), liter: ptr(11.2), liters: 0.4, want: 48, wantOK: true},
+		{name: "exactly one liter", base: ptr(48), liters: 1, want: 48, wantOK: true},
+		{name: "extra liters", base: ptr(48), liter: ptr(11.2), liters: 3, want: 70.4, wantOK: true},
+		{name: "fractional extra liter is rounded to kopecks", base: ptr(46), liter: ptr(11.25), liters: 1.5, want: 51.63, wantOK: true},
+		{name: "extra liters without liter tariff", base: ptr(48), liters: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := perLiterCost(tt.base, tt.liter, tt.liters)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("perLiterCost = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBoxTariffDeliveryCost(t *testing.T) {
+	tests := []struct {
+		name   string
+		tariff BoxTariff
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "wb values with comma",
+			tariff: BoxTariff{BoxDeliveryBase: "48", BoxDeliveryLiter: "11,2"},
+			want:   70.4,
+			wantOK: true,
+		},
+		{
+			name:   "warehouse without delivery tariff",
+			tariff: BoxTariff{BoxDeliveryBase: "-", BoxDeliveryLiter: "-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tariff.Parse()
+			got, ok := tt.tariff.DeliveryCost(3)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("DeliveryCost(3) = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
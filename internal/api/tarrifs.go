@@ -23,36 +23,17 @@ type TariffItem struct {
 
 type TariffsBox struct {
 	Data struct {
-		DtNextBox     string `json:"dtNextBox"`
-		DtTillMax     string `json:"dtTillMax"`
-		WarehouseList []struct {
-			BoxDeliveryBase                string `json:"boxDeliveryBase"`
-			BoxDeliveryCoefExpr            string `json:"boxDeliveryCoefExpr"`
-			BoxDeliveryLiter               string `json:"boxDeliveryLiter"`
-			BoxDeliveryMarketplaceBase     string `json:"boxDeliveryMarketplaceBase"`
-			BoxDeliveryMarketplaceCoefExpr string `json:"boxDeliveryMarketplaceCoefExpr"`
-			BoxDeliveryMarketplaceLiter    string `json:"boxDeliveryMarketplaceLiter"`
-			BoxStorageBase                 string `json:"boxStorageBase"`
-			BoxStorageCoefExpr             string `json:"boxStorageCoefExpr"`
-			BoxStorageLiter                string `json:"boxStorageLiter"`
-			GeoName                        string `json:"geoName"`
-			WarehouseName                  string `json:"warehouseName"`
-		} `json:"warehouseList"`
+		DtNextBox     string      `json:"dtNextBox"`
+		DtTillMax     string      `json:"dtTillMax"`
+		WarehouseList []BoxTariff `json:"warehouseList"`
 	} `json:"data"`
 }
 
 type TariffsPallet struct {
 	Data struct {
-		DtNextPallet  string `json:"dtNextPallet"`
-		DtTillMax     string `json:"dtTillMax"`
-		WarehouseList []struct {
-			PalletDeliveryExpr       string `json:"palletDeliveryExpr"`
-			PalletDeliveryValueBase  string `json:"palletDeliveryValueBase"`
-			PalletDeliveryValueLiter string `json:"palletDeliveryValueLiter"`
-			PalletStorageExpr        string `json:"palletStorageExpr"`
-			PalletStorageValueExpr   string `json:"palletStorageValueExpr"`
-			WarehouseName            string `json:"warehouseName"`
-		} `json:"warehouseList"`
+		DtNextPallet  string         `json:"dtNextPallet"`
+		DtTillMax     string         `json:"dtTillMax"`
+		WarehouseList []PalletTariff `json:"warehouseList"`
 	} `json:"data"`
 }
 
@@ -103,6 +84,9 @@ func (c *WBClient) GetTariffsBox(ctx context.Context, date string) ([]TariffsBox
 		return nil, err
 	}
 
+	for i := range resp.Response.Data.WarehouseList {
+		resp.Response.Data.WarehouseList[i].Parse()
+	}
 	allTariffs = append(allTariffs, resp.Response)
 	c.Logger.Info().Msgf("✅  tariffs loaded (%d records)", len(resp.Response.Data.WarehouseList))
 
@@ -131,6 +115,9 @@ func (c *WBClient) GetTariffsPallet(ctx context.Context, date string) ([]Tariffs
 		return nil, err
 	}
 
+	for i := range resp.Data.Data.WarehouseList {
+		resp.Data.Data.WarehouseList[i].Parse()
+	}
 	allTariffs = append(allTariffs, resp.Data)
 	c.Logger.Info().Msgf("✅  tariffs loaded (%d records)", len(resp.Data.Data.WarehouseList))
 
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"net/url"
+)
+
+// WarehouseRemain — остаток товара на одном складе
+type WarehouseRemain struct {
+	WarehouseName string `json:"warehouseName"`
+	Quantity      int    `json:"quantity"`
+}
+
+// WarehouseRemainsRow — строка отчёта об остатках на складах WB (по баркоду)
+type WarehouseRemainsRow struct {
+	Brand                  string            `json:"brand"`
+	SubjectName            string            `json:"subjectName"`
+	VendorCode             string            `json:"vendorCode"`
+	NmID                   int64             `json:"nmId"`
+	Barcode                string            `json:"barcode"`
+	TechSize               string            `json:"techSize"`
+	Volume                 float64           `json:"volume"`
+	InWayToClient          int               `json:"inWayToClient"`
+	InWayFromClient        int               `json:"inWayFromClient"`
+	QuantityWarehousesFull int               `json:"quantityWarehousesFull"`
+	Warehouses             []WarehouseRemain `json:"warehouses"`
+}
+
+// warehouseRemainsQuery — детализация до баркода: артикул, размер, бренд и предмет
+func warehouseRemainsQuery() url.Values {
+	q := url.Values{}
+	for _, g := range []string{"groupByBrand", "groupBySubject", "groupBySa", "groupByNm", "groupByBarcode", "groupBySize"} {
+		q.Set(g, "true")
+	}
+	return q
+}
+
+// StartWarehouseRemains создаёт задачи на отчёт об остатках по всем токенам
+func (c *WBClient) StartWarehouseRemains(ctx context.Context) []ReportTask {
+	return c.StartReportTasks(ctx, WarehouseRemainsReport, warehouseRemainsQuery())
+}
+
+// StartWarehouseRemainsTask создаёт задачу на отчёт об остатках для одного токена (снимок на текущий момент)
+func (c *WBClient) StartWarehouseRemainsTask(ctx context.Context, tokenIdx int) (string, error) {
+	return c.StartReportTask(ctx, tokenIdx, WarehouseRemainsReport, warehouseRemainsQuery())
+}
+
+// GetWarehouseRemainsStatus проверяет статус задачи по token_idx и task_id
+func (c *WBClient) GetWarehouseRemainsStatus(ctx context.Context, tokenIdx int, taskID string) (*ReportTaskStatus, error) {
+	return c.GetReportTaskStatus(ctx, tokenIdx, WarehouseRemainsReport, taskID)
+}
+
+// GetWarehouseRemainsDownload скачивает отчёт об остатках по token_idx и task_id
+func (c *WBClient) GetWarehouseRemainsDownload(ctx context.Context, tokenIdx int, taskID string) ([]WarehouseRemainsRow, error) {
+	var rows []WarehouseRemainsRow
+	if err := c.DownloadReportTask(ctx, tokenIdx, WarehouseRemainsReport, taskID, &rows); err != nil {
+		return nil, err
+	}
+
+	c.Logger.Info().Msgf("✅ Warehouse remains report downloaded (token_%d, records=%d)", tokenIdx, len(rows))
+	return rows, nil
+}
@@ -0,0 +1,37 @@
+package asyncreport
+
+import (
+	"context"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// PaidStorage — отчёт о платном хранении (максимум 8 дней за задачу)
+type PaidStorage struct {
+	api *api.WBClient
+}
+
+func NewPaidStorage(client *api.WBClient) *PaidStorage {
+	return &PaidStorage{api: client}
+}
+
+func (p *PaidStorage) Name() string    { return "paid_storage" }
+func (p *PaidStorage) WindowDays() int { return 8 }
+func (p *PaidStorage) SettleDays() int { return 0 }
+
+func (p *PaidStorage) Create(ctx context.Context, tokenIdx int, from, to time.Time) (string, error) {
+	return p.api.StartPaidStorageTask(ctx, tokenIdx, from.Format("2006-01-02"), to.Format("2006-01-02"))
+}
+
+func (p *PaidStorage) Status(ctx context.Context, tokenIdx int, taskID string) (string, error) {
+	st, err := p.api.GetPaidStorageStatus(ctx, tokenIdx, taskID)
+	if err != nil {
+		return "", err
+	}
+	return st.Data.Status, nil
+}
+
+func (p *PaidStorage) Download(ctx context.Context, tokenIdx int, taskID string) ([]map[string]any, error) {
+	return p.api.GetPaidStorageDownload(ctx, tokenIdx, taskID)
+}
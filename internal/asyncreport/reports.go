@@ -0,0 +1,89 @@
+package asyncreport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// WarehouseRemains — отчёт об остатках на складах WB (снимок раз в сутки)
+type WarehouseRemains struct {
+	api *api.WBClient
+}
+
+func NewWarehouseRemains(client *api.WBClient) *WarehouseRemains {
+	return &WarehouseRemains{api: client}
+}
+
+func (w *WarehouseRemains) Name() string    { return "warehouse_remains" }
+func (w *WarehouseRemains) WindowDays() int { return 0 }
+func (w *WarehouseRemains) SettleDays() int { return 0 }
+
+func (w *WarehouseRemains) Create(ctx context.Context, tokenIdx int, from, to time.Time) (string, error) {
+	return w.api.StartWarehouseRemainsTask(ctx, tokenIdx)
+}
+
+func (w *WarehouseRemains) Status(ctx context.Context, tokenIdx int, taskID string) (string, error) {
+	st, err := w.api.GetWarehouseRemainsStatus(ctx, tokenIdx, taskID)
+	if err != nil {
+		return "", err
+	}
+	return st.Data.Status, nil
+}
+
+func (w *WarehouseRemains) Download(ctx context.Context, tokenIdx int, taskID string) ([]map[string]any, error) {
+	rows, err := w.api.GetWarehouseRemainsDownload(ctx, tokenIdx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toRows(rows)
+}
+
+// Acceptance — отчёт о платной приёмке за периоды по 7 дней. Стоимость приёмки WB дописывает с задержкой,
+// поэтому период выгружается только через неделю после окончания — когда уже идёт следующий.
+type Acceptance struct {
+	api *api.WBClient
+}
+
+func NewAcceptance(client *api.WBClient) *Acceptance {
+	return &Acceptance{api: client}
+}
+
+func (a *Acceptance) Name() string    { return "acceptance_report" }
+func (a *Acceptance) WindowDays() int { return 7 }
+func (a *Acceptance) SettleDays() int { return 7 }
+
+func (a *Acceptance) Create(ctx context.Context, tokenIdx int, from, to time.Time) (string, error) {
+	return a.api.StartAcceptanceReportTask(ctx, tokenIdx, from.Format("2006-01-02"), to.Format("2006-01-02"))
+}
+
+func (a *Acceptance) Status(ctx context.Context, tokenIdx int, taskID string) (string, error) {
+	st, err := a.api.GetAcceptanceReportStatus(ctx, tokenIdx, taskID)
+	if err != nil {
+		return "", err
+	}
+	return st.Data.Status, nil
+}
+
+func (a *Acceptance) Download(ctx context.Context, tokenIdx int, taskID string) ([]map[string]any, error) {
+	rows, err := a.api.GetAcceptanceReportDownload(ctx, tokenIdx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toRows(rows)
+}
+
+// toRows переводит типизированные строки отчёта в map, чтобы Runner мог добавить метаданные
+func toRows(v any) ([]map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,288 @@
+package asyncreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// publishChunk — строк отчёта в одном сообщении Kafka
+	publishChunk = 500
+	// taskRetention — сколько хранить выполненные задачи в state
+	taskRetention = 30 * 24 * time.Hour
+	// catchUpWindows — сколько последних завершённых периодов догружать (если сервис простаивал)
+	catchUpWindows = 2
+)
+
+// windowEpoch — точка отсчёта фиксированных периодов отчётов (дата; полночь берётся в зоне now)
+var windowEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Report — асинхронный отчёт WB: создание задачи → опрос статуса → скачивание результата
+type Report interface {
+	// Name — тип события и часть ключа state, например "paid_storage"
+	Name() string
+	// WindowDays — период одной задачи в днях. Периоды фиксированные и не пересекаются (отсчёт от windowEpoch),
+	// поэтому каждый день попадает ровно в одну задачу и публикуется один раз; в работу берутся только
+	// завершённые периоды. 0 — отчёт-снимок без периода, снимается раз в сутки за сегодняшнюю дату
+	WindowDays() int
+	// SettleDays — сколько дней после окончания периода ждать перед выгрузкой: WB дописывает часть данных
+	// с задержкой, а каждый период выгружается один раз
+	SettleDays() int
+	Create(ctx context.Context, tokenIdx int, from, to time.Time) (string, error)
+	// Status возвращает статус задачи: api.ReportTaskNew, Processing, Done, Purged или Canceled
+	Status(ctx context.Context, tokenIdx int, taskID string) (string, error)
+	Download(ctx context.Context, tokenIdx int, taskID string) ([]map[string]any, error)
+}
+
+// Task — задача отчёта для токена и периода; хранится в state, чтобы после перезапуска продолжить опрос
+type Task struct {
+	TokenIdx  int       `json:"token_idx"`
+	TaskID    string    `json:"task_id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	DoneAt    time.Time `json:"done_at,omitempty"`
+	Rows      int       `json:"rows,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Runner — создаёт задачи отчёта по каждому токену, опрашивает статус с backoff,
+// скачивает результат и публикует его в Kafka
+type Runner struct {
+	report    Report
+	api       *api.WBClient
+	publisher publisher.Publisher
+	topic     string
+	state     state.Store
+	interval  time.Duration
+	logger    zerolog.Logger
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	// maxWait — сколько ждать задачу за один цикл; недождавшиеся задачи опрашиваются в следующем
+	maxWait time.Duration
+}
+
+func NewRunner(report Report, client *api.WBClient, pub publisher.Publisher, topic string, store state.Store, interval time.Duration, log zerolog.Logger) *Runner {
+	return &Runner{
+		report:     report,
+		api:        client,
+		publisher:  pub,
+		topic:      topic,
+		state:      store,
+		interval:   interval,
+		logger:     log,
+		minBackoff: 5 * time.Second,
+		maxBackoff: 2 * time.Minute,
+		maxWait:    20 * time.Minute,
+	}
+}
+
+func (r *Runner) stateKey() string {
+	return "async_report_" + r.report.Name()
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	r.logger.Info().Msgf("🚀 Starting %s report runner (interval: %s)", r.report.Name(), r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.RunOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info().Msgf("🛑 %s report runner stopped", r.report.Name())
+			return
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce проводит задачи за текущий период по всем токенам
+func (r *Runner) RunOnce(ctx context.Context) {
+	tasks := map[string]*Task{}
+	if _, err := r.state.Load(r.stateKey(), &tasks); err != nil {
+		r.logger.Error().Err(err).Msgf("❌ failed to load %s tasks", r.report.Name())
+	}
+	if tasks == nil {
+		tasks = map[string]*Task{}
+	}
+	for key, t := range tasks {
+		if time.Since(t.CreatedAt) > taskRetention {
+			delete(tasks, key)
+		}
+	}
+
+	for _, p := range reportPeriods(r.report.WindowDays(), r.report.SettleDays(), time.Now()) {
+		from, to := p[0], p[1]
+		for idx, token := range r.api.Tokens {
+			if token == "" {
+				continue
+			}
+			tokenIdx := idx + 1
+			key := fmt.Sprintf("%d|%s|%s", tokenIdx, from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+			t := tasks[key]
+			if t != nil && t.Status == api.ReportTaskDone {
+				continue
+			}
+			if t == nil {
+				t = &Task{TokenIdx: tokenIdx, From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+				tasks[key] = t
+			}
+
+			r.process(ctx, t, from, to)
+			if t.Status == api.ReportTaskPurged || t.Status == api.ReportTaskCanceled {
+				// задача потеряна — в следующем цикле будет создана заново
+				delete(tasks, key)
+			}
+			r.save(tasks)
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// reportPeriods возвращает периоды [from, to] для задач: для снимка — сегодня, для отчёта за период —
+// последние catchUpWindows фиксированных периодов по days дней, закончившихся не позже чем settle дней
+// до вчерашнего (старые первыми). Даты считаются в зоне now.
+func reportPeriods(days, settle int, now time.Time) [][2]time.Time {
+	loc := now.Location()
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if days <= 0 {
+		return [][2]time.Time{{today, today}}
+	}
+
+	// номер последнего готового дня от windowEpoch; считаем по датам в UTC,
+	// чтобы 23- и 25-часовые сутки перехода на летнее время не сбивали деление
+	ready := today.AddDate(0, 0, -1-settle)
+	readyUTC := time.Date(ready.Year(), ready.Month(), ready.Day(), 0, 0, 0, 0, time.UTC)
+	idx := int(readyUTC.Sub(windowEpoch).Hours() / 24)
+
+	epoch := time.Date(windowEpoch.Year(), windowEpoch.Month(), windowEpoch.Day(), 0, 0, 0, 0, loc)
+	// периоды [k*days, k*days+days-1]; последний — тот, что кончается не позже ready
+	lastEnd := epoch.AddDate(0, 0, ((idx+1)/days)*days-1)
+
+	periods := make([][2]time.Time, 0, catchUpWindows)
+	for i := catchUpWindows - 1; i >= 0; i-- {
+		to := lastEnd.AddDate(0, 0, -i*days)
+		from := to.AddDate(0, 0, -(days - 1))
+		if from.Before(epoch) {
+			continue
+		}
+		periods = append(periods, [2]time.Time{from, to})
+	}
+	return periods
+}
+
+// process доводит задачу до конца: создаёт (если нужно), ждёт готовности, скачивает и публикует
+func (r *Runner) process(ctx context.Context, t *Task, from, to time.Time) {
+	name := r.report.Name()
+
+	if t.TaskID == "" {
+		id, err := r.report.Create(ctx, t.TokenIdx, from, to)
+		if err != nil {
+			t.Error = err.Error()
+			r.logger.Error().Err(err).Msgf("❌ failed to create %s task (token_%d)", name, t.TokenIdx)
+			return
+		}
+		t.TaskID, t.Status, t.CreatedAt, t.Error = id, api.ReportTaskNew, time.Now(), ""
+	} else {
+		r.logger.Info().Msgf("🔁 Resuming %s task %s (token_%d)", name, t.TaskID, t.TokenIdx)
+	}
+
+	deadline := time.Now().Add(r.maxWait)
+	backoff := r.minBackoff
+	for {
+		status, err := r.report.Status(ctx, t.TokenIdx, t.TaskID)
+		if err != nil {
+			r.logger.Warn().Err(err).Msgf("⚠️ %s task %s status not available", name, t.TaskID)
+		} else {
+			t.Status = status
+		}
+
+		switch t.Status {
+		case api.ReportTaskDone:
+			r.download(ctx, t)
+			return
+		case api.ReportTaskPurged, api.ReportTaskCanceled:
+			r.logger.Warn().Msgf("⚠️ %s task %s is %s, will be recreated", name, t.TaskID, t.Status)
+			return
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			r.logger.Warn().Msgf("⏳ %s task %s still %s, will resume next cycle", name, t.TaskID, t.Status)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+func (r *Runner) download(ctx context.Context, t *Task) {
+	name := r.report.Name()
+
+	rows, err := r.report.Download(ctx, t.TokenIdx, t.TaskID)
+	if err != nil {
+		// задача снова считается незавершённой — в следующем цикле статус перепроверится и отчёт скачается заново
+		t.Status, t.Error = api.ReportTaskProcessing, err.Error()
+		r.logger.Error().Err(err).Msgf("❌ failed to download %s task %s", name, t.TaskID)
+		return
+	}
+
+	for _, row := range rows {
+		row["__token_idx"] = t.TokenIdx
+		row["__period_from"] = t.From
+		row["__period_to"] = t.To
+	}
+
+	for start := 0; start < len(rows); start += publishChunk {
+		end := start + publishChunk
+		if end > len(rows) {
+			end = len(rows)
+		}
+		event := models.WBEvent{
+			Type:      name,
+			Data:      rows[start:end],
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		// ключ — токен и период, а не ID задачи: пересозданная задача за тот же период не выглядит новыми данными
+		key := fmt.Sprintf("%d|%s|%s", t.TokenIdx, t.From, t.To)
+		if err := r.publisher.Publish(ctx, r.topic, []byte(key), event); err != nil {
+			t.Status, t.Error = api.ReportTaskProcessing, err.Error()
+			r.logger.Error().Err(err).Msgf("❌ failed to publish %s task %s", name, t.TaskID)
+			return
+		}
+	}
+
+	t.Status, t.DoneAt, t.Rows, t.Error = api.ReportTaskDone, time.Now(), len(rows), ""
+	r.logger.Info().Msgf("✅ Published %d %s rows (token_%d, %s..%s) to topic '%s'", len(rows), name, t.TokenIdx, t.From, t.To, r.topic)
+}
+
+func (r *Runner) save(tasks map[string]*Task) {
+	if err := r.state.Save(r.stateKey(), tasks); err != nil {
+		r.logger.Error().Err(err).Msgf("❌ failed to save %s tasks", r.report.Name())
+	}
+}
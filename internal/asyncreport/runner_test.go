@@ -0,0 +1,128 @@
+package asyncreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportPeriods(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		days   int
+		settle int
+		now    time.Time
+		want   [][2]string // [from, to], старые первыми
+	}{
+		{
+			name: "snapshot is today",
+			days: 0,
+			now:  time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC),
+			want: [][2]string{{"2026-10-19", "2026-10-19"}},
+		},
+		{
+			// периоды по 8 дней от 2020-01-01: ..., 10-08..10-15, 10-16..10-23
+			name: "period ending yesterday is taken",
+			days: 8,
+			now:  time.Date(2026, 10, 24, 10, 0, 0, 0, time.UTC),
+			want: [][2]string{{"2026-10-08", "2026-10-15"}, {"2026-10-16", "2026-10-23"}},
+		},
+		{
+			name: "unfinished period is not taken",
+			days: 8,
+			now:  time.Date(2026, 10, 23, 23, 59, 0, 0, time.UTC),
+			want: [][2]string{{"2026-09-30", "2026-10-07"}, {"2026-10-08", "2026-10-15"}},
+		},
+		{
+			name: "same periods all week long",
+			days: 8,
+			now:  time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			want: [][2]string{{"2026-09-30", "2026-10-07"}, {"2026-10-08", "2026-10-15"}},
+		},
+		{
+			// периоды по 7 дней: 10-07..10-13, 10-14..10-20; с задержкой 7 дней 10-14..10-20 готов только 10-28
+			name:   "settle delay holds back the last period",
+			days:   7,
+			settle: 7,
+			now:    time.Date(2026, 10, 27, 12, 0, 0, 0, time.UTC),
+			want:   [][2]string{{"2026-09-30", "2026-10-06"}, {"2026-10-07", "2026-10-13"}},
+		},
+		{
+			name:   "settled period is taken",
+			days:   7,
+			settle: 7,
+			now:    time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC),
+			want:   [][2]string{{"2026-10-07", "2026-10-13"}, {"2026-10-14", "2026-10-20"}},
+		},
+		{
+			name: "catch-up stops at epoch",
+			days: 8,
+			now:  time.Date(2020, 1, 10, 12, 0, 0, 0, time.UTC),
+			want: [][2]string{{"2020-01-01", "2020-01-08"}},
+		},
+		{
+			name: "nothing before the first period ends",
+			days: 8,
+			now:  time.Date(2020, 1, 5, 12, 0, 0, 0, time.UTC),
+			want: [][2]string{},
+		},
+		{
+			// 1 ноября 2026 в Нью-Йорке сутки длятся 25 часов — деление не должно съехать на день
+			name: "dst change does not shift periods",
+			days: 8,
+			now:  time.Date(2026, 11, 9, 0, 30, 0, 0, ny),
+			want: [][2]string{{"2026-10-24", "2026-10-31"}, {"2026-11-01", "2026-11-08"}},
+		},
+		{
+			name: "dst change inside unfinished period",
+			days: 8,
+			now:  time.Date(2026, 11, 8, 23, 30, 0, 0, ny),
+			want: [][2]string{{"2026-10-16", "2026-10-23"}, {"2026-10-24", "2026-10-31"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reportPeriods(tt.days, tt.settle, tt.now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d periods %v, want %v", len(got), got, tt.want)
+			}
+			for i, p := range got {
+				from, to := p[0].Format("2006-01-02"), p[1].Format("2006-01-02")
+				if from != tt.want[i][0] || to != tt.want[i][1] {
+					t.Errorf("period %d = %s..%s, want %s..%s", i, from, to, tt.want[i][0], tt.want[i][1])
+				}
+				if p[0].Location() != tt.now.Location() || p[0].Hour() != 0 || p[1].Hour() != 0 {
+					t.Errorf("period %d bounds %v..%v are not local midnights", i, p[0], p[1])
+				}
+			}
+		})
+	}
+}
+
+func TestReportPeriodsDoNotOverlap(t *testing.T) {
+	// за 40 дней подряд каждый день должен попасть ровно в один период
+	start := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	covered := map[string]int{}
+	seen := map[[2]time.Time]bool{}
+	for i := 0; i < 40; i++ {
+		for _, p := range reportPeriods(8, 0, start.AddDate(0, 0, i)) {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			for d := p[0]; !d.After(p[1]); d = d.AddDate(0, 0, 1) {
+				covered[d.Format("2006-01-02")]++
+			}
+		}
+	}
+	for day, n := range covered {
+		if n != 1 {
+			t.Errorf("day %s is covered by %d periods", day, n)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry — запись журнала действий, выполненных через сервис от имени продавца
+type Entry struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Action   string    `json:"action"` // например "feedback.answer", "question.viewed"
+	Target   string    `json:"target"` // ID объекта в WB (отзыв, вопрос, кампания…)
+	TokenIdx int       `json:"token_idx,omitempty"`
+	DryRun   bool      `json:"dry_run,omitempty"`
+	Payload  any       `json:"payload,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	// OutcomeUnknown — запрос к WB мог выполниться, но подтверждения нет; перед повтором нужно проверить состояние
+	OutcomeUnknown bool `json:"outcome_unknown,omitempty"`
+}
+
+// Filter — условия выборки из журнала
+type Filter struct {
+	Action string
+	User   string
+	Since  time.Time
+	Limit  int
+}
+
+// Logger — интерфейс журнала аудита
+type Logger interface {
+	Record(e Entry) error
+	List(f Filter) ([]Entry, error)
+}
+
+// FileLogger — журнал в JSON Lines файле (одна запись — одна строка)
+type FileLogger struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileLogger создаёт журнал аудита; директория файла создаётся при необходимости
+func NewFileLogger(path string) (*FileLogger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create audit dir: %w", err)
+	}
+	return &FileLogger{path: path}, nil
+}
+
+// Record дописывает запись в конец журнала
+func (l *FileLogger) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// List возвращает записи журнала по фильтру (новые в конце)
+func (l *FileLogger) List(flt Filter) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]Entry, 0)
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4<<20)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			continue
+		}
+		if flt.Action != "" && e.Action != flt.Action {
+			continue
+		}
+		if flt.User != "" && e.User != flt.User {
+			continue
+		}
+		if !flt.Since.IsZero() && e.Time.Before(flt.Since) {
+			continue
+		}
+		out = append(out, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+
+	if flt.Limit > 0 && len(out) > flt.Limit {
+		out = out[len(out)-flt.Limit:]
+	}
+	return out, nil
+}
@@ -0,0 +1,244 @@
+package catalog
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/state"
+)
+
+// stateKey — ключ каталога в state store
+const stateKey = "catalog"
+
+// Product — атрибуты товара, которыми обогащаются события продаж, заказов и остатков
+type Product struct {
+	NmID         int64     `json:"nmId"`
+	ImtID        int64     `json:"imtId"`
+	VendorCode   string    `json:"vendorCode"`
+	Title        string    `json:"title"`
+	Brand        string    `json:"brand"`
+	SubjectID    int       `json:"subjectId"`
+	SubjectName  string    `json:"subjectName"`
+	Length       float64   `json:"length"`
+	Width        float64   `json:"width"`
+	Height       float64   `json:"height"`
+	WeightBrutto float64   `json:"weightBrutto"`
+	VolumeLiters float64   `json:"volumeLiters"`
+	Barcodes     []string  `json:"barcodes"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	TokenIdx     int       `json:"token_idx"`
+}
+
+// Change — изменение карточки между двумя синхронизациями
+type Change struct {
+	Action  string   `json:"action"` // "new", "updated", "removed"
+	Product Product  `json:"product"`
+	Fields  []string `json:"fields,omitempty"`
+}
+
+// FromCard собирает Product из карточки Content API
+func FromCard(c api.ProductCard) Product {
+	return Product{
+		NmID:         c.NmID,
+		ImtID:        c.ImtID,
+		VendorCode:   c.VendorCode,
+		Title:        c.Title,
+		Brand:        c.Brand,
+		SubjectID:    c.SubjectID,
+		SubjectName:  c.SubjectName,
+		Length:       c.Dimensions.Length,
+		Width:        c.Dimensions.Width,
+		Height:       c.Dimensions.Height,
+		WeightBrutto: c.Dimensions.WeightBrutto,
+		VolumeLiters: c.VolumeLiters(),
+		Barcodes:     c.Barcodes(),
+		UpdatedAt:    c.UpdatedAt,
+		TokenIdx:     c.TokenIdx,
+	}
+}
+
+// Catalog — локальный каталог товаров (nmId → Product), сохраняется в state store
+type Catalog struct {
+	mu        sync.RWMutex
+	products  map[int64]Product
+	byBarcode map[string]int64
+	store     state.Store
+}
+
+// New создаёт каталог и загружает сохранённое состояние (если есть)
+func New(store state.Store) (*Catalog, error) {
+	c := &Catalog{
+		products:  map[int64]Product{},
+		byBarcode: map[string]int64{},
+		store:     store,
+	}
+	if store == nil {
+		return c, nil
+	}
+
+	var saved map[int64]Product
+	if _, err := store.Load(stateKey, &saved); err != nil {
+		return c, err
+	}
+	for id, p := range saved {
+		c.products[id] = p
+	}
+	c.reindex()
+	return c, nil
+}
+
+func (c *Catalog) reindex() {
+	c.byBarcode = map[string]int64{}
+	for id, p := range c.products {
+		for _, b := range p.Barcodes {
+			c.byBarcode[b] = id
+		}
+	}
+}
+
+// Lookup возвращает товар по nmId
+func (c *Catalog) Lookup(nmID int64) (Product, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.products[nmID]
+	return p, ok
+}
+
+// LookupBarcode возвращает товар по баркоду
+func (c *Catalog) LookupBarcode(barcode string) (Product, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	id, ok := c.byBarcode[barcode]
+	if !ok {
+		return Product{}, false
+	}
+	p, ok := c.products[id]
+	return p, ok
+}
+
+// List возвращает все товары, отсортированные по nmId
+func (c *Catalog) List() []Product {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]Product, 0, len(c.products))
+	for _, p := range c.products {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].NmID < out[j].NmID })
+	return out
+}
+
+// Len — количество товаров в каталоге
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.products)
+}
+
+// Replace заменяет каталог полной выгрузкой карточек, сохраняет его и возвращает изменения.
+// Товары, которых нет в выгрузке, считаются удалёнными.
+func (c *Catalog) Replace(cards []api.ProductCard) ([]Change, error) {
+	c.mu.Lock()
+	next := make(map[int64]Product, len(cards))
+	changes := make([]Change, 0)
+
+	for _, card := range cards {
+		p := FromCard(card)
+		next[p.NmID] = p
+
+		prev, ok := c.products[p.NmID]
+		if !ok {
+			changes = append(changes, Change{Action: "new", Product: p})
+			continue
+		}
+		if fields := diffFields(prev, p); len(fields) > 0 {
+			changes = append(changes, Change{Action: "updated", Product: p, Fields: fields})
+		}
+	}
+	for id, prev := range c.products {
+		if _, ok := next[id]; !ok {
+			changes = append(changes, Change{Action: "removed", Product: prev})
+		}
+	}
+
+	c.products = next
+	c.reindex()
+	c.mu.Unlock()
+
+	if c.store != nil {
+		// next больше не изменяется: каждый Replace собирает новую карту
+		if err := c.store.Save(stateKey, next); err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// diffFields — список изменившихся атрибутов товара
+func diffFields(a, b Product) []string {
+	fields := make([]string, 0)
+	if a.VendorCode != b.VendorCode {
+		fields = append(fields, "vendorCode")
+	}
+	if a.Title != b.Title {
+		fields = append(fields, "title")
+	}
+	if a.Brand != b.Brand {
+		fields = append(fields, "brand")
+	}
+	if a.SubjectID != b.SubjectID {
+		fields = append(fields, "subject")
+	}
+	if a.Length != b.Length || a.Width != b.Width || a.Height != b.Height || a.WeightBrutto != b.WeightBrutto {
+		fields = append(fields, "dimensions")
+	}
+	if len(a.Barcodes) != len(b.Barcodes) {
+		fields = append(fields, "barcodes")
+	} else {
+		for i := range a.Barcodes {
+			if a.Barcodes[i] != b.Barcodes[i] {
+				fields = append(fields, "barcodes")
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// Enrich добавляет в записи статистики (продажи, заказы, остатки) атрибуты товара в поле "__product".
+// Товар ищется по nmId, а если его нет — по баркоду.
+func (c *Catalog) Enrich(records []api.WBRecord) int {
+	if c == nil {
+		return 0
+	}
+	enriched := 0
+	for _, rec := range records {
+		p, ok := c.Lookup(recordNmID(rec))
+		if !ok {
+			if barcode, _ := rec["barcode"].(string); barcode != "" {
+				p, ok = c.LookupBarcode(barcode)
+			}
+		}
+		if !ok {
+			continue
+		}
+		rec["__product"] = p
+		enriched++
+	}
+	return enriched
+}
+
+// recordNmID достаёт nmId из записи statistics API (JSON-числа приходят как float64)
+func recordNmID(rec api.WBRecord) int64 {
+	switch v := rec["nmId"].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
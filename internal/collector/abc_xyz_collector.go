@@ -0,0 +1,163 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	abcXYZTopic        = "wb.raw.assortment"
+	abcXYZStateKey     = "abc_xyz_classes"
+	abcXYZLastStateKey = "abc_xyz_last_date"
+)
+
+// ABCXYZClassChange — смена класса товара между расчётами
+type ABCXYZClassChange struct {
+	NmID     int64                `json:"nm_id"`
+	OldClass string               `json:"old_class"` // пусто — товар впервые попал в анализ
+	NewClass string               `json:"new_class"` // пусто — продаж за окно больше нет
+	Item     analytics.ABCXYZItem `json:"item"`
+}
+
+// ABCXYZCollector — ежедневный ABC/XYZ-анализ ассортимента. Публикует матрицу и классы товаров,
+// а также отдельные события о смене класса; предыдущие классы хранятся в state.
+type ABCXYZCollector struct {
+	interval   time.Duration
+	windowDays int
+	api        *api.WBClient
+	publisher  publisher.Publisher
+	state      state.Store
+	logger     zerolog.Logger
+}
+
+func NewABCXYZCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *ABCXYZCollector {
+	interval := cfg.ABCXYZInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	window := cfg.ABCXYZWindowDays
+	if window < 14 {
+		window = 90
+	}
+	return &ABCXYZCollector{
+		interval:   interval,
+		windowDays: window,
+		api:        client,
+		publisher:  pub,
+		state:      store,
+		logger:     log,
+	}
+}
+
+func (c *ABCXYZCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting ABCXYZCollector loop (interval: %s, window: %d days)", c.interval, c.windowDays)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 ABCXYZCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *ABCXYZCollector) collectAndPublish(ctx context.Context) {
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	var last string
+	if _, err := c.state.Load(abcXYZLastStateKey, &last); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load abc/xyz state")
+	}
+	if last >= today {
+		c.logger.Info().Msgf("ℹ️ ABC/XYZ for %s already published", today)
+		return
+	}
+
+	sales, err := c.api.GetSales(ctx, now.AddDate(0, 0, -c.windowDays).Format("2006-01-02"))
+	if err != nil || len(sales) == 0 {
+		c.logger.Error().Err(err).Msg("❌ abc/xyz: no sales loaded")
+		return
+	}
+	matrix := analytics.ABCXYZ(sales, c.windowDays, now)
+
+	prev := map[int64]string{}
+	if _, err := c.state.Load(abcXYZStateKey, &prev); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load abc/xyz classes")
+	}
+	if prev == nil {
+		prev = map[int64]string{}
+	}
+
+	publish := func(typ, action, key string, data any) bool {
+		event := models.WBEvent{
+			Type:      typ,
+			Action:    action,
+			Data:      data,
+			CreatedAt: now.Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		if err := c.publisher.Publish(ctx, abcXYZTopic, []byte(key), event); err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to publish %s %s", typ, key)
+			return false
+		}
+		return true
+	}
+
+	if !publish("abc_xyz_matrix", "snapshot", "matrix_"+today, matrix) {
+		return
+	}
+
+	next := make(map[int64]string, len(matrix.Items))
+	changes := 0
+	for _, it := range matrix.Items {
+		next[it.NmID] = it.Class
+		old, seen := prev[it.NmID]
+		if seen && old == it.Class {
+			continue
+		}
+		action := "changed"
+		if !seen {
+			action = "new"
+		}
+		key := strconv.FormatInt(it.NmID, 10) + "_" + today
+		if !publish("abc_xyz_class", action, key, ABCXYZClassChange{NmID: it.NmID, OldClass: old, NewClass: it.Class, Item: it}) {
+			return
+		}
+		changes++
+	}
+	for nmID, old := range prev {
+		if _, ok := next[nmID]; ok {
+			continue
+		}
+		key := strconv.FormatInt(nmID, 10) + "_" + today
+		if !publish("abc_xyz_class", "removed", key, ABCXYZClassChange{NmID: nmID, OldClass: old}) {
+			return
+		}
+		changes++
+	}
+
+	if err := c.state.Save(abcXYZStateKey, next); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save abc/xyz classes")
+	}
+	if err := c.state.Save(abcXYZLastStateKey, today); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save abc/xyz state")
+	}
+	c.logger.Info().Msgf("✅ ABC/XYZ: %d products, %d class changes published to topic '%s'", len(matrix.Items), changes, abcXYZTopic)
+}
@@ -0,0 +1,134 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	acceptanceTopic    = "wb.raw.acceptance"
+	acceptanceStateKey = "acceptance_coefficients"
+)
+
+// acceptanceSeen — последнее опубликованное состояние приёмки склада на дату
+type acceptanceSeen struct {
+	Coefficient float64 `json:"coefficient"`
+	AllowUnload bool    `json:"allow_unload"`
+}
+
+// AcceptanceCollector — частый опрос коэффициентов приёмки складов WB.
+// Коэффициенты общие для всех продавцов, поэтому опрашиваются первым токеном;
+// публикуются только новые и изменившиеся значения.
+type AcceptanceCollector struct {
+	interval  time.Duration
+	api       *api.WBClient
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+}
+
+func NewAcceptanceCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *AcceptanceCollector {
+	interval := cfg.AcceptanceInterval
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	return &AcceptanceCollector{
+		interval:  interval,
+		api:       client,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+	}
+}
+
+func (c *AcceptanceCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting AcceptanceCollector loop (interval: %s)", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 AcceptanceCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *AcceptanceCollector) collectAndPublish(ctx context.Context) {
+	coefs, err := c.api.GetAcceptanceCoefficients(ctx, 0, nil)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to fetch acceptance coefficients")
+		return
+	}
+
+	seen := map[string]acceptanceSeen{}
+	if _, err := c.state.Load(acceptanceStateKey, &seen); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load acceptance state")
+	}
+	if seen == nil {
+		seen = map[string]acceptanceSeen{}
+	}
+
+	published := 0
+	for _, ac := range coefs {
+		date := ac.Date
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		key := fmt.Sprintf("%d|%s|%d", ac.WarehouseID, date, ac.BoxTypeID)
+		cur := acceptanceSeen{Coefficient: ac.Coefficient, AllowUnload: ac.AllowUnload}
+
+		prev, ok := seen[key]
+		if ok && prev == cur {
+			continue
+		}
+		action := "new"
+		if ok {
+			action = "updated"
+		}
+
+		event := models.WBEvent{
+			Type:      "acceptance_coefficient",
+			Action:    action,
+			Data:      ac,
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		if err := c.publisher.Publish(ctx, acceptanceTopic, []byte(key), event); err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to publish acceptance coefficient %s", key)
+			continue
+		}
+		seen[key] = cur
+		published++
+	}
+
+	// прошедшие даты больше не меняются
+	today := time.Now().Format("2006-01-02")
+	for key := range seen {
+		if parts := strings.Split(key, "|"); len(parts) == 3 && parts[1] < today {
+			delete(seen, key)
+		}
+	}
+	if err := c.state.Save(acceptanceStateKey, seen); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save acceptance state")
+	}
+
+	if published > 0 {
+		c.logger.Info().Msgf("✅ Published %d acceptance coefficient changes to topic '%s'", published, acceptanceTopic)
+	}
+}
@@ -0,0 +1,164 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	advertKeywordsTopic    = "wb.raw.adverts.keywords"
+	advertKeywordsStateKey = "advert_keywords_last_date"
+	// advertKeywordsCatchUp — на сколько дней назад догружается статистика после простоя
+	advertKeywordsCatchUp = 7
+)
+
+// AdvertKeywordsCollector — ежедневный сбор статистики по ключевым фразам и кластерам кампаний.
+// Последний выгруженный день хранится в state, чтобы после перезапуска не публиковать дни повторно.
+type AdvertKeywordsCollector struct {
+	interval  time.Duration
+	api       *api.WBClient
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+}
+
+func NewAdvertKeywordsCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *AdvertKeywordsCollector {
+	interval := cfg.AdvertKeywordsInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &AdvertKeywordsCollector{
+		interval:  interval,
+		api:       client,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+	}
+}
+
+func (c *AdvertKeywordsCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting AdvertKeywordsCollector loop (interval: %s)", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 AdvertKeywordsCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *AdvertKeywordsCollector) collectAndPublish(ctx context.Context) {
+	// собираем только завершённые дни: со дня после последнего выгруженного по вчера
+	yesterday := localDay(time.Now().AddDate(0, 0, -1))
+	from := yesterday.AddDate(0, 0, -(advertKeywordsCatchUp - 1))
+
+	var last time.Time
+	if c.state != nil {
+		if _, err := c.state.Load(advertKeywordsStateKey, &last); err != nil {
+			c.logger.Error().Err(err).Msg("❌ failed to load advert keywords state")
+		}
+	}
+	if !last.IsZero() {
+		last = localDay(last)
+	}
+	if !last.IsZero() && !last.Before(from) {
+		from = last.AddDate(0, 0, 1)
+	}
+	if from.After(yesterday) {
+		return
+	}
+
+	c.logger.Info().Msgf("📥 Collecting advert keyword stats %s..%s", from.Format("2006-01-02"), yesterday.Format("2006-01-02"))
+
+	keywords, clusters, failed := 0, 0, false
+	for idx, token := range c.api.Tokens {
+		if token == "" {
+			continue
+		}
+		tokenIdx := idx + 1
+
+		refs, err := c.api.GetAdvertIDs(ctx, tokenIdx, api.AdvertStatusActive, api.AdvertStatusPaused)
+		if err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to fetch adverts (token_%d)", tokenIdx)
+			failed = true
+			continue
+		}
+
+		for _, ref := range refs {
+			stats, err := c.api.GetAdvertKeywordStats(ctx, tokenIdx, ref.AdvertID, from, yesterday)
+			if err != nil {
+				c.logger.Error().Err(err).Msgf("❌ failed to fetch keyword stats for advert %d", ref.AdvertID)
+				failed = true
+			}
+			for _, st := range stats {
+				if c.publish(ctx, "advert_keyword_stats", ref.AdvertID, st) {
+					keywords++
+				}
+			}
+
+			// кластеры — снимок на момент сбора, у поисковых кампаний — фразы и их статистика
+			switch ref.Type {
+			case api.AdvertTypeAuto, api.AdvertTypeAuction:
+				cls, err := c.api.GetAdvertAutoClusters(ctx, tokenIdx, ref.AdvertID)
+				if err != nil {
+					c.logger.Error().Err(err).Msgf("❌ failed to fetch clusters for advert %d", ref.AdvertID)
+					continue
+				}
+				for _, cl := range cls {
+					if c.publish(ctx, "advert_clusters", ref.AdvertID, cl) {
+						clusters++
+					}
+				}
+			case api.AdvertTypeSearch:
+				words, err := c.api.GetAdvertSearchWords(ctx, tokenIdx, ref.AdvertID)
+				if err != nil {
+					c.logger.Error().Err(err).Msgf("❌ failed to fetch search words for advert %d", ref.AdvertID)
+					continue
+				}
+				if c.publish(ctx, "advert_search_words", ref.AdvertID, words) {
+					clusters++
+				}
+			}
+			time.Sleep(250 * time.Millisecond)
+		}
+	}
+
+	// при ошибках день не фиксируем — на следующем запуске он будет догружен
+	if !failed && c.state != nil {
+		if err := c.state.Save(advertKeywordsStateKey, yesterday); err != nil {
+			c.logger.Error().Err(err).Msg("❌ failed to save advert keywords state")
+		}
+	}
+
+	c.logger.Info().Msgf("✅ Published %d keyword stat rows and %d cluster records to Kafka topic '%s'", keywords, clusters, advertKeywordsTopic)
+}
+
+func (c *AdvertKeywordsCollector) publish(ctx context.Context, eventType string, advertID int64, data any) bool {
+	event := models.WBEvent{
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Source:    "wildberries",
+	}
+	if err := c.publisher.Publish(ctx, advertKeywordsTopic, []byte(strconv.FormatInt(advertID, 10)), event); err != nil {
+		c.logger.Error().Err(err).Msgf("❌ failed to publish %s for advert %d", eventType, advertID)
+		return false
+	}
+	return true
+}
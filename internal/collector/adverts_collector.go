@@ -2,15 +2,27 @@ package collector
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"wildberriesapi/internal/api"
 	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
 	"wildberriesapi/internal/publisher"
 
 	"github.com/rs/zerolog"
 )
 
+const (
+	advertsTopic = "wb.raw.adverts"
+	// advertStatsWindow — за сколько дней перезапрашивается статистика (WB досчитывает её задним числом)
+	advertStatsWindow = 7 * 24 * time.Hour
+)
+
+// advertStatsStatuses — кампании, по которым запрашивается статистика.
+// Завершённые берутся, только если менялись в пределах окна статистики.
+var advertStatsStatuses = []int{api.AdvertStatusActive, api.AdvertStatusPaused, api.AdvertStatusCompleted}
+
 type AdvertsCollector struct {
 	cfg       config.Config
 	api       *api.WBClient
@@ -33,6 +45,7 @@ func (c *AdvertsCollector) Run(ctx context.Context) {
 	ticker := time.NewTicker(c.cfg.PollInterval)
 	defer ticker.Stop()
 
+	c.collectAndPublish(ctx)
 	for {
 		select {
 		case <-ctx.Done():
@@ -47,19 +60,69 @@ func (c *AdvertsCollector) Run(ctx context.Context) {
 func (c *AdvertsCollector) collectAndPublish(ctx context.Context) {
 	c.logger.Info().Msg("📥 Collecting adverts from WB API")
 
-	adverts, err := c.api.GetAdverts(ctx)
-	if err != nil {
-		c.logger.Error().Err(err).Msg("❌ failed to fetch adverts")
-		return
-	}
+	end := time.Now()
+	begin := end.Add(-advertStatsWindow)
+
+	campaigns, stats := 0, 0
+	for idx, token := range c.api.Tokens {
+		if token == "" {
+			continue
+		}
+		tokenIdx := idx + 1
+
+		refs, err := c.api.GetAdvertIDs(ctx, tokenIdx, advertStatsStatuses...)
+		if err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to fetch adverts (token_%d)", tokenIdx)
+			continue
+		}
 
-	count := 0
-	for _, adv := range adverts {
-		err := c.publisher.Publish(ctx, "wb.raw.adverts", nil, adv)
-		if err == nil {
-			count++
+		ids := make([]int64, 0, len(refs))
+		for _, ref := range refs {
+			if ref.Status == api.AdvertStatusCompleted && ref.ChangeTime.Before(begin) {
+				continue
+			}
+			ids = append(ids, ref.AdvertID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		details, err := c.api.GetAdvertDetails(ctx, tokenIdx, ids)
+		if err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to fetch advert details (token_%d)", tokenIdx)
+		}
+		for _, adv := range details {
+			if c.publish(ctx, "advert_campaign", adv.AdvertID, adv) {
+				campaigns++
+			}
+		}
+
+		full, err := c.api.GetAdvertFullStats(ctx, tokenIdx, ids, begin, end)
+		if err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to fetch advert stats (token_%d)", tokenIdx)
+		}
+		for _, s := range full {
+			for _, row := range s.ByNmDay() {
+				if c.publish(ctx, "advert_stats", row.AdvertID, row) {
+					stats++
+				}
+			}
 		}
 	}
 
-	c.logger.Info().Msgf("✅ Published %d advert records to Kafka topic 'wb.adverts'", count)
+	c.logger.Info().Msgf("✅ Published %d campaigns and %d stat rows to Kafka topic '%s'", campaigns, stats, advertsTopic)
+}
+
+func (c *AdvertsCollector) publish(ctx context.Context, eventType string, advertID int64, data any) bool {
+	event := models.WBEvent{
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Source:    "wildberries",
+	}
+	if err := c.publisher.Publish(ctx, advertsTopic, []byte(strconv.FormatInt(advertID, 10)), event); err != nil {
+		c.logger.Error().Err(err).Msgf("❌ failed to publish %s for advert %d", eventType, advertID)
+		return false
+	}
+	return true
 }
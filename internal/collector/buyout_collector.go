@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	buyoutTopic    = "wb.raw.buyouts"
+	buyoutStateKey = "buyout_rates_last_date"
+)
+
+// BuyoutCollector — ежедневный расчёт процента выкупа и возвратов по товарам, регионам и складам
+// для каждого скользящего окна. Заказы и продажи выгружаются один раз за самое длинное окно.
+type BuyoutCollector struct {
+	interval  time.Duration
+	windows   []int
+	api       *api.WBClient
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+}
+
+func NewBuyoutCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *BuyoutCollector {
+	interval := cfg.BuyoutInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	windows := cfg.BuyoutWindows
+	if len(windows) == 0 {
+		windows = []int{7, 14, 30}
+	}
+	return &BuyoutCollector{
+		interval:  interval,
+		windows:   windows,
+		api:       client,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+	}
+}
+
+func (c *BuyoutCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting BuyoutCollector loop (interval: %s, windows: %v)", c.interval, c.windows)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 BuyoutCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *BuyoutCollector) collectAndPublish(ctx context.Context) {
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	var last string
+	if _, err := c.state.Load(buyoutStateKey, &last); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load buyout rates state")
+	}
+	if last >= today {
+		c.logger.Info().Msgf("ℹ️ Buyout rates for %s already published", today)
+		return
+	}
+
+	maxWindow := 0
+	for _, w := range c.windows {
+		if w > maxWindow {
+			maxWindow = w
+		}
+	}
+	dateFrom := now.AddDate(0, 0, -maxWindow).Format("2006-01-02")
+
+	orders, err := c.api.GetOrders(ctx, dateFrom, "")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ buyout rates: failed to fetch orders")
+		return
+	}
+	sales, err := c.api.GetSales(ctx, dateFrom)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ buyout rates: failed to fetch sales")
+		return
+	}
+	// заказы есть, а продаж за окно нет — почти наверняка сбой выгрузки; нулевой выкуп не публикуем
+	if len(orders) > 0 && len(sales) == 0 {
+		c.logger.Warn().Msgf("⚠️ buyout rates: %d orders but no sales loaded, cycle skipped", len(orders))
+		return
+	}
+
+	published := 0
+	for _, window := range c.windows {
+		for _, by := range []string{analytics.BuyoutByNm, analytics.BuyoutByRegion, analytics.BuyoutByWarehouse} {
+			for _, rate := range analytics.BuyoutRates(orders, sales, by, window, now) {
+				event := models.WBEvent{
+					Type:      "buyout_rate",
+					Action:    by,
+					Data:      rate,
+					CreatedAt: now.Format(time.RFC3339),
+					Source:    "wildberries",
+				}
+				key := by + "_" + rate.Key + "_" + strconv.Itoa(window) + "_" + today
+				if err := c.publisher.Publish(ctx, buyoutTopic, []byte(key), event); err != nil {
+					c.logger.Error().Err(err).Msgf("❌ failed to publish buyout rate %s", key)
+					return
+				}
+				published++
+			}
+		}
+	}
+
+	if err := c.state.Save(buyoutStateKey, today); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save buyout rates state")
+	}
+	c.logger.Info().Msgf("✅ Published %d buyout rates (%d orders, %d sales) to topic '%s'", published, len(orders), len(sales), buyoutTopic)
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/models"
+)
+
+// CollectCards синхронизирует локальный каталог с Content API и публикует изменения карточек
+func (c *Collector) CollectCards(ctx context.Context) {
+	if c.Catalog == nil {
+		return
+	}
+
+	c.Logger.Info().Msg("🗂️ Syncing product cards catalogue")
+	cards, err := c.API.GetCards(ctx)
+	if err != nil {
+		// неполная выгрузка дала бы ложные события removed и потерю атрибутов для обогащения
+		c.Logger.Error().Err(err).Msg("❌ Failed to fetch product cards, catalogue left unchanged")
+		return
+	}
+
+	// пустая выгрузка почти всегда означает ошибку API — не затираем каталог
+	if len(cards) == 0 {
+		c.Logger.Warn().Msg("⚠️ Content API returned no cards, catalogue left unchanged")
+		return
+	}
+
+	changes, err := c.Catalog.Replace(cards)
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to save catalogue")
+	}
+
+	count := 0
+	for _, ch := range changes {
+		event := models.WBEvent{
+			Type:      "cards",
+			Action:    ch.Action,
+			Data:      ch,
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		key := []byte(strconv.FormatInt(ch.Product.NmID, 10))
+		if err := c.Publisher.Publish(ctx, "wb.raw.cards", key, event); err == nil {
+			count++
+		}
+	}
+
+	c.Logger.Info().Msgf("✅ Catalogue synced: %d products, %d changes published to topic '%s'", c.Catalog.Len(), count, "wb.raw.cards")
+}
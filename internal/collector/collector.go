@@ -6,23 +6,32 @@ import (
 	"sync"
 	"time"
 	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/catalog"
 	"wildberriesapi/internal/config"
 	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
 )
 
 type Collector struct {
 	API          *api.WBClient
 	Publisher    publisher.Publisher
+	State        state.Store
+	Catalog      *catalog.Catalog
 	Logger       zerolog.Logger
 	PollInterval time.Duration
+	// FeedbackReread — окно перечитывания отзывов и вопросов назад от курсора
+	FeedbackReread time.Duration
 }
 
-func NewCollector(cfg config.Config, API *api.WBClient, pub publisher.Publisher, Logger zerolog.Logger) *Collector {
+func NewCollector(cfg config.Config, API *api.WBClient, pub publisher.Publisher, store state.Store, cat *catalog.Catalog, Logger zerolog.Logger) *Collector {
 	return &Collector{
-		API:          API,
-		Publisher:    pub,
-		Logger:       Logger,
-		PollInterval: cfg.PollInterval,
+		API:            API,
+		Publisher:      pub,
+		State:          store,
+		Catalog:        cat,
+		Logger:         Logger,
+		PollInterval:   cfg.PollInterval,
+		FeedbackReread: time.Duration(cfg.FeedbackRereadDays) * 24 * time.Hour,
 	}
 }
 
@@ -36,6 +45,9 @@ func (c *Collector) Schedule(ctx context.Context) {
 		case <-ticker.C:
 			c.Logger.Info().Msg("🚀 Starting WB full data collection cycle...")
 
+			// Каталог нужен для обогащения продаж/заказов/остатков — синхронизируем его первым
+			c.CollectCards(ctx)
+
 			// Запускаем всё параллельно
 			var wg sync.WaitGroup
 
@@ -67,6 +79,7 @@ func (c *Collector) Schedule(ctx context.Context) {
 			go func() {
 				defer wg.Done()
 				c.collectAndPublishTarrifs(ctx)
+				c.CollectTariffHistory(ctx)
 			}()
 
 			wg.Add(1)
@@ -78,17 +91,22 @@ func (c *Collector) Schedule(ctx context.Context) {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
+				// оба отчёта делят лимит nm-report (3 запроса в минуту) — запускаем последовательно
 				c.CollectDailyReports(ctx)
+				c.CollectGroupedReports(ctx)
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.CollectSearchTexts(ctx)
 			}()
 
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				payload := map[string]interface{}{
-					"dateFrom": time.Now().AddDate(0, 0, -7).Format("2006-01-02"),
-					"dateTo":   time.Now().Format("2006-01-02"),
-				}
-				c.CollectAndPublishSearchText(ctx, payload)
+				c.CollectFeedbacks(ctx)
+				c.CollectQuestions(ctx)
 			}()
 
 			wg.Wait()
@@ -100,3 +118,11 @@ func (c *Collector) Schedule(ctx context.Context) {
 		}
 	}
 }
+
+// localDay — начало дня t в локальной зоне сервиса. Truncate(24*time.Hour) здесь не подходит:
+// он режет по полуночи UTC, и в МСК до 03:00 выбирался бы не тот день.
+func localDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	fbsOrdersTopic  = "wb.raw.fbs.orders"
+	fbsSeenStateKey = "fbs_orders_seen"
+	// fbsSeenRetention — сколько помнить ID после того, как задание пропало из /orders/new
+	fbsSeenRetention  = 7 * 24 * time.Hour
+	defaultFBSPolling = time.Minute
+)
+
+// FBSOrdersCollector — частый опрос новых сборочных заданий FBS.
+// Задание остаётся в /orders/new, пока его не добавят в поставку, поэтому
+// уже опубликованные ID запоминаются в state и повторно не публикуются.
+// Для каждого ID хранится время, когда задание последний раз было в выдаче: пока задание
+// висит в /orders/new, ID не вычищается, сколько бы оно там ни пролежало.
+type FBSOrdersCollector struct {
+	interval  time.Duration
+	api       *api.WBClient
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+	seen      map[int64]time.Time
+}
+
+func NewFBSOrdersCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *FBSOrdersCollector {
+	interval := cfg.FBSPollInterval
+	if interval <= 0 {
+		interval = defaultFBSPolling
+	}
+	return &FBSOrdersCollector{
+		interval:  interval,
+		api:       client,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+		seen:      map[int64]time.Time{},
+	}
+}
+
+func (c *FBSOrdersCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting FBSOrdersCollector loop (interval: %s)", c.interval)
+
+	if c.state != nil {
+		if _, err := c.state.Load(fbsSeenStateKey, &c.seen); err != nil {
+			c.logger.Error().Err(err).Msg("❌ failed to load FBS seen orders")
+		}
+		if c.seen == nil {
+			c.seen = map[int64]time.Time{}
+		}
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 FBSOrdersCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *FBSOrdersCollector) collectAndPublish(ctx context.Context) {
+	orders, err := c.api.GetNewFBSOrders(ctx)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to fetch new FBS orders")
+		return
+	}
+
+	now := time.Now()
+	count := 0
+	for _, o := range orders {
+		if _, ok := c.seen[o.ID]; ok {
+			c.seen[o.ID] = now
+			continue
+		}
+
+		event := models.WBEvent{
+			Type:      "fbs_orders",
+			Action:    "new",
+			Data:      o,
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		if err := c.publisher.Publish(ctx, fbsOrdersTopic, []byte(strconv.FormatInt(o.ID, 10)), event); err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to publish FBS order %d", o.ID)
+			continue
+		}
+		c.seen[o.ID] = now
+		count++
+	}
+
+	for id, at := range c.seen {
+		if now.Sub(at) > fbsSeenRetention {
+			delete(c.seen, id)
+		}
+	}
+
+	// сохраняем каждый цикл: иначе обновлённые отметки «последний раз в выдаче» теряются при рестарте
+	if c.state != nil {
+		if err := c.state.Save(fbsSeenStateKey, c.seen); err != nil {
+			c.logger.Error().Err(err).Msg("❌ failed to save FBS seen orders")
+		}
+	}
+	if count == 0 {
+		return
+	}
+	c.logger.Info().Msgf("✅ Published %d new FBS orders to topic '%s'", count, fbsOrdersTopic)
+}
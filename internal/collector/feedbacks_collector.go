@@ -0,0 +1,206 @@
+package collector
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/models"
+)
+
+const (
+	// feedbackInitialWindow — глубина первой выгрузки, когда курсора ещё нет
+	feedbackInitialWindow = 30 * 24 * time.Hour
+	// defaultFeedbackReread — сколько перечитываем назад от курсора, если FEEDBACK_REREAD_DAYS не задан
+	defaultFeedbackReread = 7 * 24 * time.Hour
+)
+
+// feedbackCursor — инкрементальный курсор для отзывов/вопросов (хранится в state).
+// Since ведётся по каждому токену отдельно: сбой одного токена не сдвигает курсор остальных.
+type feedbackCursor struct {
+	Since map[int]time.Time   `json:"since_by_token"`
+	Seen  map[string]seenItem `json:"seen"`
+}
+
+type seenItem struct {
+	Hash      string    `json:"hash"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+	TokenIdx  int       `json:"token_idx"`
+}
+
+// trackedItem — общее представление отзыва или вопроса для инкрементальной синхронизации
+type trackedItem struct {
+	ID        string
+	CreatedAt time.Time
+	Answer    string
+	TokenIdx  int
+	Data      any
+}
+
+// CollectFeedbacks публикует новые и изменившиеся отзывы в wb.raw.reviews
+func (c *Collector) CollectFeedbacks(ctx context.Context) {
+	c.syncTracked(ctx, "feedbacks", "wb.raw.reviews", func(tokenIdx int, since time.Time) ([]trackedItem, error) {
+		items := make([]trackedItem, 0)
+		for _, answered := range []bool{false, true} {
+			list, err := c.API.GetFeedbacks(ctx, api.FeedbackQuery{TokenIdx: tokenIdx, IsAnswered: answered, DateFrom: since})
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range list {
+				answer := ""
+				if f.Answer != nil {
+					answer = f.Answer.Text
+				}
+				items = append(items, trackedItem{ID: f.ID, CreatedAt: f.CreatedDate, Answer: answer, TokenIdx: f.TokenIdx, Data: f})
+			}
+		}
+		return items, nil
+	})
+}
+
+// CollectQuestions публикует новые и изменившиеся вопросы в wb.raw.questions
+func (c *Collector) CollectQuestions(ctx context.Context) {
+	c.syncTracked(ctx, "questions", "wb.raw.questions", func(tokenIdx int, since time.Time) ([]trackedItem, error) {
+		items := make([]trackedItem, 0)
+		for _, answered := range []bool{false, true} {
+			list, err := c.API.GetQuestions(ctx, api.FeedbackQuery{TokenIdx: tokenIdx, IsAnswered: answered, DateFrom: since})
+			if err != nil {
+				return nil, err
+			}
+			for _, q := range list {
+				answer := ""
+				if q.Answer != nil {
+					answer = q.Answer.Text
+				}
+				items = append(items, trackedItem{ID: q.ID, CreatedAt: q.CreatedDate, Answer: answer, TokenIdx: q.TokenIdx, Data: q})
+			}
+		}
+		return items, nil
+	})
+}
+
+// syncTracked — общий цикл: читает курсор, выгружает записи по каждому токену, публикует новые/изменённые
+// и сохраняет курсор. Курсор токена, выгрузка которого не удалась, не сдвигается.
+func (c *Collector) syncTracked(ctx context.Context, kind, topic string, fetch func(tokenIdx int, since time.Time) ([]trackedItem, error)) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	reread := c.FeedbackReread
+	if reread <= 0 {
+		reread = defaultFeedbackReread
+	}
+
+	stateKey := kind + "_cursor"
+	cursor := feedbackCursor{Since: map[int]time.Time{}, Seen: map[string]seenItem{}}
+	if c.State != nil {
+		if _, err := c.State.Load(stateKey, &cursor); err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to load %s cursor, starting from scratch", kind)
+		}
+		if cursor.Since == nil {
+			cursor.Since = map[int]time.Time{}
+		}
+		if cursor.Seen == nil {
+			cursor.Seen = map[string]seenItem{}
+		}
+	}
+
+	published, answers := 0, 0
+	for idx, token := range c.API.Tokens {
+		if token == "" {
+			continue
+		}
+		tokenIdx := idx + 1
+
+		since := time.Now().Add(-feedbackInitialWindow)
+		if last, ok := cursor.Since[tokenIdx]; ok {
+			since = last.Add(-reread)
+		}
+
+		c.Logger.Info().Msgf("💬 Collecting WB %s for token_%d since %s", kind, tokenIdx, since.Format(time.RFC3339))
+		items, err := fetch(tokenIdx, since)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.Logger.Error().Err(err).Msgf("❌ Failed to collect WB %s (token_%d)", kind, tokenIdx)
+			continue
+		}
+
+		for _, it := range items {
+			b, _ := json.Marshal(it.Data)
+			sum := sha1.Sum(b)
+			hash := hex.EncodeToString(sum[:])
+
+			prev, known := cursor.Seen[it.ID]
+			if known && prev.Hash == hash {
+				continue
+			}
+
+			action := "new"
+			if known {
+				action = "updated"
+			}
+
+			event := models.WBEvent{
+				Type:      kind,
+				Action:    action,
+				Data:      it.Data,
+				CreatedAt: time.Now().Format(time.RFC3339),
+				Source:    "wildberries",
+			}
+			if err := c.Publisher.Publish(ctx, topic, []byte(it.ID), event); err != nil {
+				c.Logger.Error().Err(err).Msgf("❌ failed to publish %s id=%s", kind, it.ID)
+				continue
+			}
+			published++
+
+			if it.Answer != "" && it.Answer != prev.Answer {
+				answerEvent := models.WBEvent{
+					Type:   kind + "_answer",
+					Action: action,
+					Data: map[string]any{
+						"id":        it.ID,
+						"text":      it.Answer,
+						"token_idx": it.TokenIdx,
+					},
+					CreatedAt: time.Now().Format(time.RFC3339),
+					Source:    "wildberries",
+				}
+				if err := c.Publisher.Publish(ctx, "wb.raw.answers", []byte(it.ID), answerEvent); err != nil {
+					c.Logger.Error().Err(err).Msgf("❌ failed to publish answer for %s id=%s", kind, it.ID)
+				} else {
+					answers++
+				}
+			}
+
+			cursor.Seen[it.ID] = seenItem{Hash: hash, Answer: it.Answer, CreatedAt: it.CreatedAt, TokenIdx: tokenIdx}
+			if it.CreatedAt.After(cursor.Since[tokenIdx]) {
+				cursor.Since[tokenIdx] = it.CreatedAt
+			}
+		}
+	}
+
+	// чистим то, что уже не попадёт в окно перечитывания своего токена
+	for id, s := range cursor.Seen {
+		horizon := time.Now().Add(-feedbackInitialWindow)
+		if last, ok := cursor.Since[s.TokenIdx]; ok {
+			horizon = last.Add(-reread)
+		}
+		if s.CreatedAt.Before(horizon) {
+			delete(cursor.Seen, id)
+		}
+	}
+
+	if c.State != nil {
+		if err := c.State.Save(stateKey, cursor); err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to save %s cursor", kind)
+		}
+	}
+
+	c.Logger.Info().Msgf("✅ Published %d %s (%d answers) to topic '%s'", published, kind, answers, topic)
+}
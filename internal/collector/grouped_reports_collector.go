@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/models"
+)
+
+// groupedReportsStateKey — последний выгруженный день групповой воронки по каждому токену
+const groupedReportsStateKey = "nm_report_grouped_last_date"
+
+// CollectGroupedReports — воронка по группам (предмет + бренд + ярлык) по дням.
+// Дни догружаются с последнего выгруженного, но не дальше недели — глубже WB историю не отдаёт.
+func (r *Collector) CollectGroupedReports(ctx context.Context) {
+	yesterday := localDay(time.Now().AddDate(0, 0, -1))
+
+	last := map[int]string{}
+	if _, err := r.State.Load(groupedReportsStateKey, &last); err != nil {
+		r.Logger.Error().Err(err).Msg("❌ failed to load grouped nm report state")
+	}
+	if last == nil {
+		last = map[int]string{}
+	}
+
+	for idx, token := range r.API.Tokens {
+		if token == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		tokenIdx := idx + 1
+
+		from := yesterday.AddDate(0, 0, -(reportsCatchUp - 1))
+		if d, err := time.ParseInLocation("2006-01-02", last[tokenIdx], time.Local); err == nil && !d.Before(from) {
+			from = d.AddDate(0, 0, 1)
+		}
+		if from.After(yesterday) {
+			continue
+		}
+		dateFrom, dateTo := from.Format("2006-01-02"), yesterday.Format("2006-01-02")
+
+		stats, err := r.API.GetNMReportGroupedHistory(ctx, tokenIdx, api.NMGroupFilter{}, dateFrom, dateTo)
+		if err != nil {
+			r.Logger.Error().Err(err).Msgf("❌ failed to collect grouped nm report (token_%d)", tokenIdx)
+			continue
+		}
+
+		published, failed := 0, false
+		for _, st := range stats {
+			event := models.WBEvent{
+				Type:      "nm_report_grouped_history",
+				Data:      st,
+				CreatedAt: time.Now().Format(time.RFC3339),
+				Source:    "wildberries",
+			}
+			key := []byte(fmt.Sprintf("%d|%d|%s|%d|%s", tokenIdx, st.SubjectID, st.BrandName, st.TagID, st.Date))
+			if err := r.Publisher.Publish(ctx, reportsTopic, key, event); err != nil {
+				r.Logger.Error().Err(err).Msg("❌ failed to publish grouped nm report")
+				failed = true
+				continue
+			}
+			published++
+		}
+		r.Logger.Info().Msgf("✅ Published %d grouped NM report records (token_%d, %s..%s) to topic '%s'", published, tokenIdx, dateFrom, dateTo, reportsTopic)
+
+		if !failed {
+			last[tokenIdx] = dateTo
+			if err := r.State.Save(groupedReportsStateKey, last); err != nil {
+				r.Logger.Error().Err(err).Msg("❌ failed to save grouped nm report state")
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	measurementPenaltiesTopic    = "wb.raw.measurement_penalties"
+	measurementPenaltiesStateKey = "measurement_penalties_seen"
+	// measurementPenaltiesWindow — WB подтверждает и сторнирует удержания с задержкой, поэтому окно перечитывается
+	measurementPenaltiesWindow = 14
+)
+
+// MeasurementPenaltiesCollector — удержания за занижение габаритов упаковки по всем токенам.
+// Отчёт перечитывается за скользящее окно; публикуются только новые и изменившиеся замеры (ключ — token/dimId).
+type MeasurementPenaltiesCollector struct {
+	interval  time.Duration
+	api       *api.WBClient
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+}
+
+func NewMeasurementPenaltiesCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *MeasurementPenaltiesCollector {
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &MeasurementPenaltiesCollector{
+		interval:  interval,
+		api:       client,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+	}
+}
+
+func (c *MeasurementPenaltiesCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting MeasurementPenaltiesCollector loop (interval: %s)", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 MeasurementPenaltiesCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *MeasurementPenaltiesCollector) collectAndPublish(ctx context.Context) {
+	// seen — token/dimId → отпечаток замера (дата, сумма, подтверждение)
+	seen := map[string]string{}
+	if _, err := c.state.Load(measurementPenaltiesStateKey, &seen); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load measurement penalties state")
+	}
+	if seen == nil {
+		seen = map[string]string{}
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -measurementPenaltiesWindow)
+	next := map[string]string{}
+	published := 0
+
+	for idx, token := range c.api.Tokens {
+		if token == "" {
+			continue
+		}
+		tokenIdx := idx + 1
+		prefix := strconv.Itoa(tokenIdx) + "/"
+
+		rows, err := c.api.GetMeasurementPenalties(ctx, tokenIdx, from, now)
+		if err != nil {
+			c.logger.Error().Err(err).Msgf("❌ measurement penalties: fetch failed (token_%d), keeping previous state", tokenIdx)
+			for k, v := range seen {
+				if strings.HasPrefix(k, prefix) {
+					next[k] = v
+				}
+			}
+			continue
+		}
+
+		for _, p := range rows {
+			key := prefix + strconv.FormatInt(p.DimID, 10)
+			fp := fmt.Sprintf("%s|%.2f|%t", p.DtBonus, p.PenaltyAmount, p.IsValid)
+			old, ok := seen[key]
+			if ok && old == fp {
+				next[key] = fp
+				continue
+			}
+
+			action := "new"
+			if ok {
+				action = "updated"
+			}
+			event := models.WBEvent{
+				Type:      "measurement_penalty",
+				Action:    action,
+				Data:      p,
+				CreatedAt: now.Format(time.RFC3339),
+				Source:    "wildberries",
+			}
+			if err := c.publisher.Publish(ctx, measurementPenaltiesTopic, []byte(key), event); err != nil {
+				c.logger.Error().Err(err).Msgf("❌ failed to publish measurement penalty %s", key)
+				// отпечаток не обновляем — событие повторится в следующем цикле
+				if ok {
+					next[key] = old
+				}
+				continue
+			}
+			next[key] = fp
+			published++
+		}
+	}
+
+	if err := c.state.Save(measurementPenaltiesStateKey, next); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save measurement penalties state")
+	}
+	c.logger.Info().Msgf("✅ Measurement penalties: %d new or updated published to topic '%s'", published, measurementPenaltiesTopic)
+}
@@ -34,6 +34,8 @@ func (c *Collector) CollectOrders(ctx context.Context) {
 		return
 	}
 
+	c.Catalog.Enrich(data)
+
 	if err := c.Publisher.Publish(ctx, "wb.raw.orders", []byte("orders"), data); err != nil {
 		c.Logger.Error().Err(err).Msg("❌ Failed to publish WB orders to Kafka")
 		return
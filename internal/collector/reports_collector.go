@@ -2,48 +2,189 @@ package collector
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/models"
+)
+
+const (
+	reportsTopic    = "wb.raw.reports"
+	reportsStateKey = "nm_report_last_date"
+	// reportsDetailStateKey — прогресс постраничной выгрузки детального отчёта
+	reportsDetailStateKey = "nm_report_detail_checkpoint"
+	// reportsDetailMaxErrors — сколько ошибок страниц допускается за одну выгрузку
+	reportsDetailMaxErrors = 5
+	// reportsCatchUp — история воронки доступна WB только за последнюю неделю
+	reportsCatchUp = 7
 )
 
+// CollectDailyReports — воронка продаж по артикулам: список nmID берётся из детального отчёта
+// (при ошибке — из каталога), затем по ним загружается история по дням.
+// Последний выгруженный день хранится в state по каждому токену, чтобы дни не публиковались повторно.
 func (r *Collector) CollectDailyReports(ctx context.Context) {
-	now := time.Now().AddDate(0, 0, -1) // вчера
-	begin := now.Format("2006-01-02") + " 00:00:00"
-	end := now.Format("2006-01-02") + " 23:59:59"
+	yesterday := localDay(time.Now().AddDate(0, 0, -1))
 
-	select {
-	case <-ctx.Done():
-		r.Logger.Warn().Msg("context canceled, stop reports collector")
-		return
-	default:
+	last := map[int]string{}
+	if _, err := r.State.Load(reportsStateKey, &last); err != nil {
+		r.Logger.Error().Err(err).Msg("❌ failed to load nm report state")
+	}
+	if last == nil {
+		last = map[int]string{}
 	}
 
-	r.Logger.Info().Msgf("📊 Collecting NM Reports for supplier=%d")
+	for idx, token := range r.API.Tokens {
+		if token == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			r.Logger.Warn().Msg("context canceled, stop reports collector")
+			return
+		}
+		tokenIdx := idx + 1
 
-	detail, err := r.API.GetNMReportDetailYesterday(ctx, begin, end)
-	if err != nil {
-		r.Logger.Error().Err(err).Msg("failed to collect nm report detail")
-		return
+		from := yesterday.AddDate(0, 0, -(reportsCatchUp - 1))
+		if d, err := time.ParseInLocation("2006-01-02", last[tokenIdx], time.Local); err == nil && !d.Before(from) {
+			from = d.AddDate(0, 0, 1)
+		}
+		if from.After(yesterday) {
+			continue
+		}
+
+		dateFrom, dateTo := from.Format("2006-01-02"), yesterday.Format("2006-01-02")
+		r.Logger.Info().Msgf("📊 Collecting NM Reports (token_%d) for %s..%s", tokenIdx, dateFrom, dateTo)
+
+		nmIDs, complete := r.reportNmIDs(ctx, tokenIdx, dateFrom, dateTo)
+		if ctx.Err() != nil {
+			return
+		}
+		if len(nmIDs) == 0 {
+			r.Logger.Warn().Msgf("⚠️ no nmIDs for NM report history (token_%d)", tokenIdx)
+			continue
+		}
+
+		history, err := r.API.GetNMReportHistory(ctx, tokenIdx, nmIDs, dateFrom, dateTo)
+		if err != nil {
+			r.Logger.Error().Err(err).Msgf("❌ failed to collect nm report history (token_%d)", tokenIdx)
+		}
+
+		// неполный детальный отчёт или ошибка пачки истории — период будет выгружен повторно
+		partial := err != nil || !complete
+		published, failed := 0, partial
+		for _, rec := range history {
+			event := models.WBEvent{
+				Type:       "nm_report_history",
+				Data:       rec,
+				CreatedAt:  time.Now().Format(time.RFC3339),
+				Source:     "wildberries",
+				Incomplete: partial,
+			}
+			key := []byte(fmt.Sprintf("%d_%s", rec.NmID, rec.Date))
+			if err := r.Publisher.Publish(ctx, reportsTopic, key, event); err != nil {
+				r.Logger.Error().Err(err).Msgf("❌ failed to publish nm report history for nmID %d", rec.NmID)
+				failed = true
+				continue
+			}
+			published++
+		}
+		r.Logger.Info().Msgf("✅ Published %d NM report history records (token_%d) to topic '%s'", published, tokenIdx, reportsTopic)
+
+		// при ошибках период не фиксируем — на следующем запуске он будет догружен
+		if !failed {
+			last[tokenIdx] = dateTo
+			if err := r.State.Save(reportsStateKey, last); err != nil {
+				r.Logger.Error().Err(err).Msg("❌ failed to save nm report state")
+			}
+		}
+	}
+}
+
+// nmDetailCheckpoint — прогресс постраничной выгрузки детального отчёта токена.
+// Загруженные карточки хранятся в state до публикации, чтобы после падения продолжить со следующей страницы.
+type nmDetailCheckpoint struct {
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	NextPage int                `json:"next_page"`
+	Cards    []api.NMReportItem `json:"cards"`
+}
+
+// reportNmIDs выгружает детальный отчёт за период, публикует его и возвращает артикулы.
+// complete = false, если отчёт выгружен не полностью — тогда артикулы дополняются из каталога.
+func (r *Collector) reportNmIDs(ctx context.Context, tokenIdx int, dateFrom, dateTo string) ([]int64, bool) {
+	checkpoints := map[int]*nmDetailCheckpoint{}
+	if _, err := r.State.Load(reportsDetailStateKey, &checkpoints); err != nil {
+		r.Logger.Error().Err(err).Msg("❌ failed to load nm report detail checkpoint")
+	}
+	if checkpoints == nil {
+		checkpoints = map[int]*nmDetailCheckpoint{}
 	}
 
-	history, err := r.API.GetNMReportHistoryBatched(ctx, []int{}, begin[:10], end[:10])
+	cp := checkpoints[tokenIdx]
+	if cp == nil || cp.From != dateFrom || cp.To != dateTo {
+		cp = &nmDetailCheckpoint{From: dateFrom, To: dateTo, NextPage: 1}
+	} else {
+		r.Logger.Info().Msgf("🔁 Resuming NM report detail (token_%d) from page %d", tokenIdx, cp.NextPage)
+	}
+
+	err := r.API.WalkNMReportDetail(ctx, tokenIdx, dateFrom+" 00:00:00", dateTo+" 23:59:59", cp.NextPage, reportsDetailMaxErrors,
+		func(page int, cards []api.NMReportItem) error {
+			cp.Cards = append(cp.Cards, cards...)
+			cp.NextPage = page + 1
+			checkpoints[tokenIdx] = cp
+			return r.State.Save(reportsDetailStateKey, checkpoints)
+		})
+	if ctx.Err() != nil {
+		// остановка сервиса — прогресс сохранён, продолжим при следующем запуске
+		return nil, false
+	}
+	complete := err == nil
 	if err != nil {
-		r.Logger.Error().Err(err).Msg("failed to collect nm report history")
-		return
+		r.Logger.Error().Err(err).Msgf("❌ NM report detail incomplete (token_%d, pages=%d)", tokenIdx, cp.NextPage-1)
 	}
 
-	reportPayload := map[string]any{
-		"date":      begin[:10],
-		"detail":    detail,
-		"history":   history,
-		"timestamp": time.Now().Format(time.RFC3339),
+	// страницы могли сдвинуться между запросами — одна карточка публикуется один раз
+	seen := make(map[int64]bool, len(cp.Cards))
+	nmIDs := make([]int64, 0, len(cp.Cards))
+	for _, card := range cp.Cards {
+		nmID := card.NmID()
+		if nmID == 0 || seen[nmID] {
+			continue
+		}
+		seen[nmID] = true
+		nmIDs = append(nmIDs, nmID)
+
+		card["__token_idx"] = tokenIdx
+		card["__period_from"] = dateFrom
+		card["__period_to"] = dateTo
+		event := models.WBEvent{
+			Type:       "nm_report_detail",
+			Data:       card,
+			CreatedAt:  time.Now().Format(time.RFC3339),
+			Source:     "wildberries",
+			Incomplete: !complete,
+		}
+		if err := r.Publisher.Publish(ctx, reportsTopic, []byte(strconv.FormatInt(nmID, 10)), event); err != nil {
+			r.Logger.Error().Err(err).Msgf("❌ failed to publish nm report detail for nmID %d", nmID)
+		}
 	}
 
-	b, _ := json.Marshal(reportPayload)
-	key := []byte("nm_reports_" + begin[:10])
-	if err := r.Publisher.Publish(ctx, "wb.raw.reports", key, b); err != nil {
-		r.Logger.Error().Err(err).Msg("❌ failed to publish nm report to Kafka")
-	} else {
-		r.Logger.Info().Msg("✅ NM report published to Kafka topic")
+	delete(checkpoints, tokenIdx)
+	if err := r.State.Save(reportsDetailStateKey, checkpoints); err != nil {
+		r.Logger.Error().Err(err).Msg("❌ failed to save nm report detail checkpoint")
+	}
+
+	if complete || r.Catalog == nil {
+		return nmIDs, complete
+	}
+
+	// детальный отчёт выгрузился не полностью — дополняем артикулами из каталога
+	for _, p := range r.Catalog.List() {
+		if (p.TokenIdx == tokenIdx || p.TokenIdx == 0) && !seen[p.NmID] {
+			seen[p.NmID] = true
+			nmIDs = append(nmIDs, p.NmID)
+		}
 	}
+	return nmIDs, complete
 }
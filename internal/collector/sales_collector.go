@@ -21,6 +21,8 @@ func (c *Collector) CollectSales(ctx context.Context) {
 		return
 	}
 
+	c.Catalog.Enrich(data)
+
 	if err := c.Publisher.Publish(ctx, "wb.raw.sales", []byte("sales"), data); err != nil {
 		c.Logger.Error().Err(err).Msg("❌ Failed to publish WB sales to Kafka")
 		return
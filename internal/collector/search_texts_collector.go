@@ -2,66 +2,158 @@ package collector
 
 import (
 	"context"
-	"encoding/json"
-	_ "fmt"
-	"strconv"
+	"fmt"
+	"math"
 	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/models"
+)
+
+const (
+	searchTextsTopic = "wb.raw.searchtexts"
+	// searchTextsStateKey — последний выгруженный день по каждому токену
+	searchTextsStateKey = "search_texts_last_date"
+	// searchPositionsStateKey — последняя известная позиция по паре артикул+запрос
+	searchPositionsStateKey = "search_texts_positions"
+	// searchPositionsRetention — позиции запросов, не встречавшихся дольше, забываются
+	searchPositionsRetention = 30 * 24 * time.Hour
 )
 
-// CollectAndPublishSearchText — вызывает PostSearchTexts для каждого токена и публикует результат в Kafka.
-// payload — сформированный body для POST (например, dateFrom/dateTo, filters и т.д.).
-func (sc *Collector) CollectAndPublishSearchText(ctx context.Context, payload map[string]interface{}) {
-	if ctx.Err() != nil {
-		sc.Logger.Warn().Msg("context cancelled before start")
+// searchPosition — средняя позиция артикула по запросу на дату
+type searchPosition struct {
+	Position float64 `json:"position"`
+	Date     string  `json:"date"`
+}
+
+// SearchPositionChange — изменение средней позиции артикула по запросу между двумя днями
+type SearchPositionChange struct {
+	NmID         int64   `json:"nmId"`
+	Text         string  `json:"text"`
+	Date         string  `json:"date"`
+	PrevDate     string  `json:"prev_date"`
+	PrevPosition float64 `json:"prev_position"`
+	Position     float64 `json:"position"`
+	Delta        float64 `json:"delta"` // > 0 — артикул опустился в выдаче
+	TokenIdx     int     `json:"token_idx"`
+}
+
+// CollectSearchTexts — ежедневный отчёт по поисковым запросам для артикулов каталога:
+// частотность, средняя позиция и конверсии за вчера, плюс события об изменении позиции.
+func (c *Collector) CollectSearchTexts(ctx context.Context) {
+	if c.Catalog == nil || c.Catalog.Len() == 0 {
+		c.Logger.Warn().Msg("⚠️ catalog is empty, skipping search-texts")
 		return
 	}
 
-	tokens := sc.API.Tokens
+	date := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
 
-	for i, token := range tokens {
-		// graceful stop if cancelled
+	last := map[int]string{}
+	if _, err := c.State.Load(searchTextsStateKey, &last); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to load search-texts state")
+	}
+	if last == nil {
+		last = map[int]string{}
+	}
+	positions := map[string]searchPosition{}
+	if _, err := c.State.Load(searchPositionsStateKey, &positions); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to load search positions")
+	}
+	if positions == nil {
+		positions = map[string]searchPosition{}
+	}
+
+	for idx, token := range c.API.Tokens {
+		if token == "" || last[idx+1] == date {
+			continue
+		}
 		if ctx.Err() != nil {
-			sc.Logger.Warn().Msg("context cancelled, stopping search-texts collector")
+			c.Logger.Warn().Msg("context cancelled, stopping search-texts collector")
 			return
 		}
+		tokenIdx := idx + 1
 
-		var supplierID int
-
-		sc.Logger.Info().Msgf("🔎 Calling search-texts for supplier=%d (token_index=%d)", supplierID, i+1)
+		var nmIDs []int64
+		for _, p := range c.Catalog.List() {
+			if p.TokenIdx == tokenIdx || p.TokenIdx == 0 {
+				nmIDs = append(nmIDs, p.NmID)
+			}
+		}
+		if len(nmIDs) == 0 {
+			continue
+		}
 
-		respMap, err := sc.API.PostSearchTexts(ctx, payload, token)
+		texts, err := c.API.GetSearchTexts(ctx, tokenIdx, api.SearchTextsQuery{NmIDs: nmIDs, From: date, To: date})
 		if err != nil {
-			sc.Logger.Error().Err(err).Msgf("❌ PostSearchTexts failed for supplier=%d", supplierID)
-			// если это таймаут/сетевая ошибка — предлагаем retry или просто продолжим дальше
+			// день не фиксируем — на следующем цикле он будет выгружен заново
+			c.Logger.Error().Err(err).Msgf("❌ search-texts failed (token_%d)", tokenIdx)
 			continue
 		}
 
-		// Если backend вернул объект с "error" — залогируем и опубликуем как ошибочный результат (по необходимости)
-		if _, hasErr := respMap["error"]; hasErr {
-			sc.Logger.Error().Msgf("search-texts returned error for supplier=%d: %+v", supplierID, respMap["error"])
-			// можно публиковать ошибки в отдельный топик; здесь — публикуем тоже (опционально)
+		published, changes := 0, 0
+		for _, t := range texts {
+			key := fmt.Sprintf("%d|%s", t.NmID, t.Text)
+			if c.publishSearchText(ctx, "search_text", key, t) {
+				published++
+			}
+
+			pos := t.AvgPosition.Current
+			if pos <= 0 {
+				continue
+			}
+			if prev, ok := positions[key]; ok && prev.Date < date && math.Abs(pos-prev.Position) >= 0.5 {
+				change := SearchPositionChange{
+					NmID:         t.NmID,
+					Text:         t.Text,
+					Date:         date,
+					PrevDate:     prev.Date,
+					PrevPosition: prev.Position,
+					Position:     pos,
+					Delta:        math.Round((pos-prev.Position)*10) / 10,
+					TokenIdx:     tokenIdx,
+				}
+				if c.publishSearchText(ctx, "search_position_change", key, change) {
+					changes++
+				}
+			}
+			positions[key] = searchPosition{Position: pos, Date: date}
 		}
 
-		// Добавляем метаданные supplier_id
-		respMap["__supplier_id"] = supplierID
-		respMap["__fetched_at"] = time.Now().Format(time.RFC3339)
-
-		// Публикация — используем supplierID как key (строка)
-		key := []byte(strconv.Itoa(supplierID))
-		// Publisher signature: Publish(ctx, topic, key, v)
-		if err := sc.Publisher.Publish(ctx, "wb.raw.searchtexts", key, respMap); err != nil {
-			sc.Logger.Error().Err(err).Msgf("failed to publish search-texts for supplier=%d", supplierID)
-		} else {
-			// логируем размер данных для мониторинга (примерно)
-			b, _ := json.Marshal(respMap)
-			sc.Logger.Info().Msgf("📤 Published search-texts for supplier=%d (bytes=%d)", supplierID, len(b))
+		c.Logger.Info().Msgf("✅ Published %d search-texts and %d position changes (token_%d) to topic '%s'", published, changes, tokenIdx, searchTextsTopic)
+
+		// позиции сохраняются раньше отметки о дне: если процесс прервётся между ними, день просто перевыгрузится,
+		// а сравнение с prev.Date < date не даст повторить события об изменении позиций
+		if err := c.State.Save(searchPositionsStateKey, positions); err != nil {
+			c.Logger.Error().Err(err).Msg("❌ failed to save search positions")
+			continue
+		}
+		last[tokenIdx] = date
+		if err := c.State.Save(searchTextsStateKey, last); err != nil {
+			c.Logger.Error().Err(err).Msg("❌ failed to save search-texts state")
 		}
+	}
 
-		// Небольшая пауза между токенами, чтобы снизить риск 429
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(sc.API.RetryDelay):
+	cutoff := time.Now().Add(-searchPositionsRetention).Format("2006-01-02")
+	for key, p := range positions {
+		if p.Date < cutoff {
+			delete(positions, key)
 		}
 	}
+	if err := c.State.Save(searchPositionsStateKey, positions); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to save search positions")
+	}
+}
+
+func (c *Collector) publishSearchText(ctx context.Context, eventType, key string, data any) bool {
+	event := models.WBEvent{
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Source:    "wildberries",
+	}
+	if err := c.Publisher.Publish(ctx, searchTextsTopic, []byte(key), event); err != nil {
+		c.Logger.Error().Err(err).Msgf("❌ failed to publish %s %s", eventType, key)
+		return false
+	}
+	return true
 }
@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	stockForecastTopic    = "wb.raw.stock_forecast"
+	stockForecastStateKey = "stock_forecast_status"
+)
+
+// StockForecastCollector — прогноз обнуления остатков по товарам и складам WB.
+// Предупреждение публикуется, когда запас опускается ниже порога или товар заканчивается;
+// после пополнения — событие recovered. Последний статус каждой пары товар/склад хранится в state.
+type StockForecastCollector struct {
+	interval      time.Duration
+	velocityDays  int
+	thresholdDays float64
+	api           *api.WBClient
+	publisher     publisher.Publisher
+	state         state.Store
+	logger        zerolog.Logger
+}
+
+func NewStockForecastCollector(cfg config.Config, client *api.WBClient, pub publisher.Publisher, store state.Store, log zerolog.Logger) *StockForecastCollector {
+	interval := cfg.StockForecastInterval
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+	velocity := cfg.StockVelocityDays
+	if velocity <= 0 {
+		velocity = 14
+	}
+	threshold := cfg.StockCoverThresholdDays
+	if threshold <= 0 {
+		threshold = 14
+	}
+	return &StockForecastCollector{
+		interval:      interval,
+		velocityDays:  velocity,
+		thresholdDays: threshold,
+		api:           client,
+		publisher:     pub,
+		state:         store,
+		logger:        log,
+	}
+}
+
+func (c *StockForecastCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting StockForecastCollector loop (interval: %s, threshold: %.0f days)", c.interval, c.thresholdDays)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 StockForecastCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *StockForecastCollector) collectAndPublish(ctx context.Context) {
+	now := time.Now()
+
+	// полный срез остатков — с давней даты изменения; при ошибке любого токена цикл пропускается,
+	// иначе товары этого токена выглядели бы закончившимися
+	stocks, err := c.api.GetStocks(ctx, "2019-06-20")
+	if err != nil || len(stocks) == 0 {
+		c.logger.Error().Err(err).Msg("❌ stock forecast: no stocks loaded")
+		return
+	}
+	orders, err := c.api.GetOrders(ctx, now.AddDate(0, 0, -c.velocityDays).Format("2006-01-02"), "")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ stock forecast: failed to fetch orders")
+		return
+	}
+
+	covers := analytics.StockCoverage(stocks, orders, c.velocityDays, c.thresholdDays, now)
+
+	prev := map[string]string{}
+	if _, err := c.state.Load(stockForecastStateKey, &prev); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load stock forecast state")
+	}
+	if prev == nil {
+		prev = map[string]string{}
+	}
+
+	next := make(map[string]string, len(covers))
+	warnings, recovered := 0, 0
+	for _, sc := range covers {
+		key := sc.Key()
+		next[key] = sc.Status
+		was := prev[key]
+		if was == "" {
+			was = analytics.CoverOK
+		}
+
+		var action string
+		switch {
+		case sc.Status == was:
+			continue
+		case sc.Status == analytics.CoverOK:
+			action = "recovered"
+		case sc.Status == analytics.CoverLow && was == analytics.CoverOut:
+			// пополнили, но мало — отдельное предупреждение не нужно
+			continue
+		default:
+			action = sc.Status
+		}
+
+		event := models.WBEvent{
+			Type:      "stock_cover_warning",
+			Action:    action,
+			Data:      sc,
+			CreatedAt: now.Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		if err := c.publisher.Publish(ctx, stockForecastTopic, []byte(key), event); err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to publish stock forecast for %s", key)
+			// статус не меняем — событие повторится в следующем цикле
+			next[key] = prev[key]
+			continue
+		}
+		if action == "recovered" {
+			recovered++
+		} else {
+			warnings++
+		}
+	}
+
+	if err := c.state.Save(stockForecastStateKey, next); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save stock forecast state")
+	}
+	c.logger.Info().Msgf("✅ Stock forecast: %d positions, %d warnings, %d recovered published to topic '%s'",
+		len(covers), warnings, recovered, stockForecastTopic)
+}
@@ -20,6 +20,8 @@ func (c *Collector) CollectStocks(ctx context.Context) {
 		return
 	}
 
+	c.Catalog.Enrich(data)
+
 	if err := c.Publisher.Publish(ctx, "wb.raw.stocks", []byte("stocks"), data); err != nil {
 		c.Logger.Error().Err(err).Msg("❌ Failed to publish WB stocks to Kafka")
 		return
@@ -2,6 +2,10 @@ package collector
 
 import (
 	"context"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/models"
 )
 
 func (c *Collector) collectAndPublishTarrifs(ctx context.Context) {
@@ -23,3 +27,135 @@ func (c *Collector) collectAndPublishTarrifs(ctx context.Context) {
 
 	c.Logger.Info().Msgf("✅ Published %d tariff records to Kafka topic 'wb.tariffs'", count)
 }
+
+const (
+	// tariffSnapshotStateKey — префикс ключей ежедневных снимков ("tariffs_snapshot_2024-05-01")
+	tariffSnapshotStateKey = "tariffs_snapshot"
+	// tariffSnapshotDatesKey — даты сохранённых снимков по возрастанию
+	tariffSnapshotDatesKey = "tariffs_snapshot_dates"
+	tariffHistoryStateKey  = "tariffs_history"
+	// tariffHistoryRetention — сколько дней хранить снимки и изменения тарифов в state
+	tariffHistoryRetention = 90
+)
+
+func tariffSnapshotKey(date string) string {
+	return tariffSnapshotStateKey + "_" + date
+}
+
+// CollectTariffHistory — раз в день снимает комиссии, коробные и паллетные тарифы, сохраняет снимок дня,
+// сравнивает с предыдущим снимком и публикует изменения как события tariff_changed
+func (c *Collector) CollectTariffHistory(ctx context.Context) {
+	today := time.Now().Format("2006-01-02")
+
+	var dates []string
+	if _, err := c.State.Load(tariffSnapshotDatesKey, &dates); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to load tariff snapshot dates")
+		return
+	}
+
+	var prev analytics.TariffSnapshot
+	if len(dates) > 0 {
+		if _, err := c.State.Load(tariffSnapshotKey(dates[len(dates)-1]), &prev); err != nil {
+			c.Logger.Error().Err(err).Msg("❌ failed to load tariff snapshot")
+			return
+		}
+	}
+	if prev.Date == today {
+		return
+	}
+
+	// снимок сохраняется только целиком — иначе пропавшие виды тарифов выглядели бы как удалённые
+	commission, err := c.API.GetTariffs(ctx)
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to fetch commission tariffs")
+		return
+	}
+	box, err := c.API.GetTariffsBox(ctx, today)
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to fetch box tariffs")
+		return
+	}
+	pallet, err := c.API.GetTariffsPallet(ctx, today)
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to fetch pallet tariffs")
+		return
+	}
+
+	cur := analytics.NewTariffSnapshot(today, commission, box, pallet)
+	if len(prev.Values) == 0 {
+		c.Logger.Info().Msgf("📸 First tariff snapshot (%d values), nothing to compare", len(cur.Values))
+		c.saveTariffSnapshot(cur, dates)
+		return
+	}
+
+	changes := analytics.DiffTariffs(prev, cur)
+	published, failed := 0, 0
+	for _, ch := range changes {
+		event := models.WBEvent{
+			Type:      "tariff_changed",
+			Action:    ch.Action,
+			Data:      ch,
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		key := []byte(ch.Kind + "|" + ch.Entity + "|" + ch.Field)
+		if err := c.Publisher.Publish(ctx, "wb.raw.tariffs", key, event); err != nil {
+			c.Logger.Error().Err(err).Msgf("❌ failed to publish tariff change %s", key)
+			failed++
+			continue
+		}
+		published++
+	}
+	// снимок не сдвигаем: в следующем цикле изменения посчитаются от того же снимка и будут отправлены снова
+	if failed > 0 {
+		c.Logger.Warn().Msgf("⚠️ %d of %d tariff changes not published, snapshot %s kept for retry", failed, len(changes), prev.Date)
+		return
+	}
+
+	var history []analytics.TariffChange
+	if _, err := c.State.Load(tariffHistoryStateKey, &history); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to load tariff history")
+	}
+	cutoff := time.Now().AddDate(0, 0, -tariffHistoryRetention).Format("2006-01-02")
+	kept := history[:0]
+	for _, h := range history {
+		if h.Date >= cutoff {
+			kept = append(kept, h)
+		}
+	}
+	history = append(kept, changes...)
+
+	if err := c.State.Save(tariffHistoryStateKey, history); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to save tariff history")
+	}
+	c.saveTariffSnapshot(cur, dates)
+
+	c.Logger.Info().Msgf("✅ Tariffs %s vs %s: %d changes, %d published to 'wb.raw.tariffs'", today, prev.Date, len(changes), published)
+}
+
+// saveTariffSnapshot сохраняет снимок дня под своей датой и удаляет снимки старше tariffHistoryRetention дней
+func (c *Collector) saveTariffSnapshot(cur analytics.TariffSnapshot, dates []string) {
+	if err := c.State.Save(tariffSnapshotKey(cur.Date), cur); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to save tariff snapshot")
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -tariffHistoryRetention).Format("2006-01-02")
+	kept := make([]string, 0, len(dates)+1)
+	for _, d := range dates {
+		if d >= cutoff && d != cur.Date {
+			kept = append(kept, d)
+			continue
+		}
+		if d != cur.Date {
+			if err := c.State.Delete(tariffSnapshotKey(d)); err != nil {
+				c.Logger.Error().Err(err).Msgf("❌ failed to delete tariff snapshot %s", d)
+			}
+		}
+	}
+	kept = append(kept, cur.Date)
+
+	if err := c.State.Save(tariffSnapshotDatesKey, kept); err != nil {
+		c.Logger.Error().Err(err).Msg("❌ failed to save tariff snapshot dates")
+	}
+}
@@ -0,0 +1,107 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/config"
+	"wildberriesapi/internal/economics"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	unitEconomicsTopic    = "wb.raw.unit_economics"
+	unitEconomicsStateKey = "unit_economics_last_date"
+	// unitEconomicsWindow — за сколько последних дней считается юнит-экономика
+	unitEconomicsWindow = 7
+)
+
+// UnitEconomicsCollector — ежедневный расчёт юнит-экономики товаров за последние 7 дней.
+// Дата последнего расчёта хранится в state, чтобы после перезапуска не публиковать день повторно.
+type UnitEconomicsCollector struct {
+	interval  time.Duration
+	economics *economics.Service
+	publisher publisher.Publisher
+	state     state.Store
+	logger    zerolog.Logger
+}
+
+func NewUnitEconomicsCollector(cfg config.Config, svc *economics.Service, pub publisher.Publisher, store state.Store, log zerolog.Logger) *UnitEconomicsCollector {
+	interval := cfg.UnitEconomicsInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &UnitEconomicsCollector{
+		interval:  interval,
+		economics: svc,
+		publisher: pub,
+		state:     store,
+		logger:    log,
+	}
+}
+
+func (c *UnitEconomicsCollector) Run(ctx context.Context) {
+	c.logger.Info().Msgf("🚀 Starting UnitEconomicsCollector loop (interval: %s)", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.collectAndPublish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("🛑 UnitEconomicsCollector stopped")
+			return
+		case <-ticker.C:
+			c.collectAndPublish(ctx)
+		}
+	}
+}
+
+func (c *UnitEconomicsCollector) collectAndPublish(ctx context.Context) {
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -(unitEconomicsWindow - 1))
+	dateTo := to.Format("2006-01-02")
+
+	var last string
+	if _, err := c.state.Load(unitEconomicsStateKey, &last); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to load unit economics state")
+	}
+	if last >= dateTo {
+		c.logger.Info().Msgf("ℹ️ Unit economics for %s already published", dateTo)
+		return
+	}
+
+	rows, err := c.economics.Calculate(ctx, from, to, "")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to calculate unit economics")
+		return
+	}
+
+	published := 0
+	for _, u := range rows {
+		event := models.WBEvent{
+			Type:      "unit_economics",
+			Action:    "snapshot",
+			Data:      u,
+			CreatedAt: time.Now().Format(time.RFC3339),
+			Source:    "wildberries",
+		}
+		key := strconv.FormatInt(u.NmID, 10) + "_" + dateTo
+		if err := c.publisher.Publish(ctx, unitEconomicsTopic, []byte(key), event); err != nil {
+			c.logger.Error().Err(err).Msgf("❌ failed to publish unit economics for nmID %d", u.NmID)
+			return
+		}
+		published++
+	}
+
+	if err := c.state.Save(unitEconomicsStateKey, dateTo); err != nil {
+		c.logger.Error().Err(err).Msg("❌ failed to save unit economics state")
+	}
+	c.logger.Info().Msgf("✅ Published %d unit economics rows for %s to topic '%s'", published, dateTo, unitEconomicsTopic)
+}
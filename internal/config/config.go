@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -19,6 +20,54 @@ type Config struct {
 	Kafka        KafkaConfig
 	LogLevel     string
 	HTTPTimeout  time.Duration
+	StateDir     string
+
+	// FBSPollInterval — частота опроса новых сборочных заданий FBS
+	FBSPollInterval time.Duration
+
+	// FeedbackRereadDays — сколько дней назад от курсора перечитываются отзывы и вопросы, чтобы поймать ответы и правки
+	FeedbackRereadDays int
+
+	// FBSStockFeed — файл (CSV/JSON) с остатками складов продавца; пусто — синхронизация только через HTTP
+	FBSStockFeed         string
+	FBSStockSyncInterval time.Duration
+	FBSStockSyncTokenIdx int
+	FBSStockSyncDryRun   bool
+
+	// AdvertKeywordsInterval — частота сбора статистики по ключевым фразам рекламы
+	AdvertKeywordsInterval time.Duration
+
+	// AcceptanceInterval — частота опроса коэффициентов приёмки складов WB
+	AcceptanceInterval time.Duration
+
+	// StockForecastInterval — частота прогноза обнуления остатков; предупреждения — если запаса
+	// меньше StockCoverThresholdDays дней при скорости заказов за StockVelocityDays дней
+	StockForecastInterval   time.Duration
+	StockVelocityDays       int
+	StockCoverThresholdDays float64
+
+	// BuyoutInterval — частота расчёта процента выкупа и возвратов за окна BuyoutWindows (в днях)
+	BuyoutInterval time.Duration
+	BuyoutWindows  []int
+
+	// ABCXYZInterval — частота ABC/XYZ-анализа ассортимента по продажам за ABCXYZWindowDays дней
+	ABCXYZInterval   time.Duration
+	ABCXYZWindowDays int
+
+	// CostFile — CSV/JSON себестоимости товаров для юнит-экономики
+	CostFile               string
+	UnitEconomicsWarehouse string
+	UnitEconomicsInterval  time.Duration
+
+	// AdvertRulesFile — JSON с правилами автоматического управления рекламой
+	AdvertRulesFile     string
+	AdvertRulesInterval time.Duration
+	AdvertRulesDryRun   bool
+
+	// APIKeys — ключ доступа → имя пользователя (для записи в WB и журнала аудита)
+	APIKeys             map[string]string
+	AuditLogPath        string
+	AnswerTemplatesFile string
 }
 
 func Load() Config {
@@ -28,14 +77,41 @@ func Load() Config {
 	v.AutomaticEnv()
 
 	v.SetDefault("POLL_INTERVAL", "30m")
+	v.SetDefault("FBS_POLL_INTERVAL", "1m")
+	v.SetDefault("FEEDBACK_REREAD_DAYS", 7)
+	v.SetDefault("FBS_STOCK_SYNC_INTERVAL", "15m")
+	v.SetDefault("ADVERT_KEYWORDS_INTERVAL", "24h")
+	v.SetDefault("ADVERT_RULES_INTERVAL", "1h")
+	v.SetDefault("ACCEPTANCE_INTERVAL", "30m")
+	v.SetDefault("STOCK_FORECAST_INTERVAL", "6h")
+	v.SetDefault("STOCK_VELOCITY_DAYS", 14)
+	v.SetDefault("STOCK_COVER_THRESHOLD_DAYS", 14)
+	v.SetDefault("BUYOUT_INTERVAL", "24h")
+	v.SetDefault("BUYOUT_WINDOWS", "7,14,30")
+	v.SetDefault("ABC_XYZ_INTERVAL", "24h")
+	v.SetDefault("ABC_XYZ_WINDOW_DAYS", 90)
+	v.SetDefault("UNIT_ECONOMICS_INTERVAL", "24h")
+	v.SetDefault("UNIT_ECONOMICS_WAREHOUSE", "Коледино")
+	v.SetDefault("ADVERT_RULES_DRY_RUN", true)
 	v.SetDefault("KAFKA_TOPIC", "wb.raw")
 	v.SetDefault("KAFKA_BROKERS", "kafka:9092")
 	v.SetDefault("LOG_LEVEL", "info")
 	v.SetDefault("HTTP_TIMEOUT", "30s")
 	v.SetDefault("SERVER_PORT", "8000")
+	v.SetDefault("STATE_DIR", "./state")
+	v.SetDefault("AUDIT_LOG_PATH", "./state/audit.jsonl")
 
 	poll, _ := time.ParseDuration(v.GetString("POLL_INTERVAL"))
 	httpTimeout, _ := time.ParseDuration(v.GetString("HTTP_TIMEOUT"))
+	fbsPoll, _ := time.ParseDuration(v.GetString("FBS_POLL_INTERVAL"))
+	stockSync, _ := time.ParseDuration(v.GetString("FBS_STOCK_SYNC_INTERVAL"))
+	advertKeywords, _ := time.ParseDuration(v.GetString("ADVERT_KEYWORDS_INTERVAL"))
+	advertRules, _ := time.ParseDuration(v.GetString("ADVERT_RULES_INTERVAL"))
+	acceptance, _ := time.ParseDuration(v.GetString("ACCEPTANCE_INTERVAL"))
+	stockForecast, _ := time.ParseDuration(v.GetString("STOCK_FORECAST_INTERVAL"))
+	buyout, _ := time.ParseDuration(v.GetString("BUYOUT_INTERVAL"))
+	abcXYZ, _ := time.ParseDuration(v.GetString("ABC_XYZ_INTERVAL"))
+	unitEconomics, _ := time.ParseDuration(v.GetString("UNIT_ECONOMICS_INTERVAL"))
 
 	brokers := []string{}
 	rawBrokers := v.GetString("KAFKA_BROKERS")
@@ -45,6 +121,22 @@ func Load() Config {
 		}
 	}
 
+	buyoutWindows := []int{}
+	for _, w := range splitAndTrim(v.GetString("BUYOUT_WINDOWS"), ",") {
+		if n, err := strconv.Atoi(w); err == nil && n > 0 {
+			buyoutWindows = append(buyoutWindows, n)
+		}
+	}
+
+	// API_KEYS="alice:key1,bob:key2"
+	apiKeys := map[string]string{}
+	for _, pair := range splitAndTrim(v.GetString("API_KEYS"), ",") {
+		user, key, ok := strings.Cut(pair, ":")
+		if ok && key != "" {
+			apiKeys[strings.TrimSpace(key)] = strings.TrimSpace(user)
+		}
+	}
+
 	return Config{
 		WBToken:      v.GetString("WB_TOKEN"),
 		ServerPort:   v.GetString("SERVER_PORT"),
@@ -55,6 +147,42 @@ func Load() Config {
 		},
 		LogLevel:    v.GetString("LOG_LEVEL"),
 		HTTPTimeout: httpTimeout,
+		StateDir:    v.GetString("STATE_DIR"),
+
+		FBSPollInterval: fbsPoll,
+
+		FeedbackRereadDays: v.GetInt("FEEDBACK_REREAD_DAYS"),
+
+		FBSStockFeed:         v.GetString("FBS_STOCK_FEED"),
+		FBSStockSyncInterval: stockSync,
+		FBSStockSyncTokenIdx: v.GetInt("FBS_STOCK_SYNC_TOKEN_IDX"),
+		FBSStockSyncDryRun:   v.GetBool("FBS_STOCK_SYNC_DRY_RUN"),
+
+		AdvertKeywordsInterval: advertKeywords,
+
+		AcceptanceInterval: acceptance,
+
+		StockForecastInterval:   stockForecast,
+		StockVelocityDays:       v.GetInt("STOCK_VELOCITY_DAYS"),
+		StockCoverThresholdDays: v.GetFloat64("STOCK_COVER_THRESHOLD_DAYS"),
+
+		BuyoutInterval: buyout,
+		BuyoutWindows:  buyoutWindows,
+
+		ABCXYZInterval:   abcXYZ,
+		ABCXYZWindowDays: v.GetInt("ABC_XYZ_WINDOW_DAYS"),
+
+		CostFile:               v.GetString("COST_FILE"),
+		UnitEconomicsWarehouse: v.GetString("UNIT_ECONOMICS_WAREHOUSE"),
+		UnitEconomicsInterval:  unitEconomics,
+
+		AdvertRulesFile:     v.GetString("ADVERT_RULES_FILE"),
+		AdvertRulesInterval: advertRules,
+		AdvertRulesDryRun:   v.GetBool("ADVERT_RULES_DRY_RUN"),
+
+		APIKeys:             apiKeys,
+		AuditLogPath:        v.GetString("AUDIT_LOG_PATH"),
+		AnswerTemplatesFile: v.GetString("ANSWER_TEMPLATES_FILE"),
 	}
 }
 
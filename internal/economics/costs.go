@@ -0,0 +1,155 @@
+package economics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Cost — себестоимость единицы товара. Товар задаётся nm_id или vendor_code (артикулом продавца).
+type Cost struct {
+	NmID       int64   `json:"nm_id"`
+	VendorCode string  `json:"vendor_code"`
+	Cost       float64 `json:"cost"`
+}
+
+// Costs — себестоимость по nmId и артикулу продавца
+type Costs struct {
+	byNm     map[int64]float64
+	byVendor map[string]float64
+}
+
+// Lookup ищет себестоимость сначала по nmId, затем по артикулу продавца (без учёта регистра)
+func (c Costs) Lookup(nmID int64, vendorCode string) (float64, bool) {
+	if v, ok := c.byNm[nmID]; ok {
+		return v, true
+	}
+	v, ok := c.byVendor[strings.ToLower(strings.TrimSpace(vendorCode))]
+	return v, ok
+}
+
+// Len — количество позиций в файле себестоимости
+func (c Costs) Len() int {
+	return len(c.byNm) + len(c.byVendor)
+}
+
+// LoadCosts читает файл себестоимости: .csv — CSV, иначе JSON. Пустой путь — пустой набор.
+func LoadCosts(path string) (Costs, error) {
+	if path == "" {
+		return newCosts(nil)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Costs{}, fmt.Errorf("open cost file: %w", err)
+	}
+	defer f.Close()
+
+	var items []Cost
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		items, err = ParseCostsCSV(f)
+	} else {
+		items, err = ParseCostsJSON(f)
+	}
+	if err != nil {
+		return Costs{}, err
+	}
+	return newCosts(items)
+}
+
+func newCosts(items []Cost) (Costs, error) {
+	c := Costs{byNm: map[int64]float64{}, byVendor: map[string]float64{}}
+	for i, it := range items {
+		if it.Cost < 0 {
+			return Costs{}, fmt.Errorf("cost file item %d: cost must not be negative", i+1)
+		}
+		switch {
+		case it.NmID > 0:
+			c.byNm[it.NmID] = it.Cost
+		case strings.TrimSpace(it.VendorCode) != "":
+			c.byVendor[strings.ToLower(strings.TrimSpace(it.VendorCode))] = it.Cost
+		default:
+			return Costs{}, fmt.Errorf("cost file item %d: nm_id or vendor_code is required", i+1)
+		}
+	}
+	return c, nil
+}
+
+// ParseCostsJSON разбирает файл вида [{"nm_id": 123, "cost": 450.5}, {"vendor_code": "A-1", "cost": 300}]
+func ParseCostsJSON(r io.Reader) ([]Cost, error) {
+	var items []Cost
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("parse cost file json: %w", err)
+	}
+	return items, nil
+}
+
+// ParseCostsCSV разбирает файл с заголовком nm_id и/или vendor_code и cost (разделитель «,» или «;»,
+// дробная часть — через точку или запятую)
+func ParseCostsCSV(r io.Reader) ([]Cost, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(strings.NewReader(string(raw)))
+	firstLine, _, _ := strings.Cut(string(raw), "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		cr.Comma = ';'
+	}
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse cost file csv: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := map[string]int{}
+	for i, h := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := col["cost"]; !ok {
+		return nil, fmt.Errorf("cost file csv: missing column %q", "cost")
+	}
+	nmCol, hasNm := col["nm_id"]
+	vcCol, hasVC := col["vendor_code"]
+	if !hasNm && !hasVC {
+		return nil, fmt.Errorf("cost file csv: nm_id or vendor_code column is required")
+	}
+
+	cell := func(row []string, i int) string {
+		if i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	items := make([]Cost, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		line := n + 2
+		var it Cost
+		if hasNm {
+			if v := cell(row, nmCol); v != "" {
+				if it.NmID, err = strconv.ParseInt(v, 10, 64); err != nil {
+					return nil, fmt.Errorf("cost file csv line %d: invalid nm_id", line)
+				}
+			}
+		}
+		if hasVC {
+			it.VendorCode = cell(row, vcCol)
+		}
+		if it.Cost, err = strconv.ParseFloat(strings.Replace(cell(row, col["cost"]), ",", ".", 1), 64); err != nil {
+			return nil, fmt.Errorf("cost file csv line %d: invalid cost", line)
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
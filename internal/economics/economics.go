@@ -0,0 +1,283 @@
+// Package economics собирает из WB данные для юнит-экономики товаров: цены, комиссии, тарифы логистики,
+// платное хранение, рекламу и выкупы, — и объединяет их с файлом себестоимости продавца.
+package economics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/catalog"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultWarehouse — склад, по тарифу которого считается логистика, если другой не задан
+	DefaultWarehouse = "Коледино"
+	// storageWindowDays — максимальный период одной задачи отчёта о платном хранении
+	storageWindowDays = 8
+	// pricesPageLimit — размер страницы выгрузки цен
+	pricesPageLimit = 1000
+)
+
+// advertStatuses — кампании, расходы которых относятся на товары
+var advertStatuses = []int{api.AdvertStatusActive, api.AdvertStatusPaused, api.AdvertStatusCompleted}
+
+// Service — расчёт юнит-экономики по данным WB и файлу себестоимости
+type Service struct {
+	api       *api.WBClient
+	catalog   *catalog.Catalog
+	costFile  string
+	warehouse string
+	logger    zerolog.Logger
+
+	// storageWait — сколько ждать отчёт о платном хранении; не дождались — хранение попадает в Missing
+	storageWait time.Duration
+}
+
+// NewService создаёт сервис. costFile — CSV/JSON себестоимости (перечитывается при каждом расчёте),
+// warehouse — склад для логистики по умолчанию.
+func NewService(client *api.WBClient, cat *catalog.Catalog, costFile, warehouse string, log zerolog.Logger) *Service {
+	if warehouse == "" {
+		warehouse = DefaultWarehouse
+	}
+	return &Service{
+		api:         client,
+		catalog:     cat,
+		costFile:    costFile,
+		warehouse:   warehouse,
+		logger:      log,
+		storageWait: 3 * time.Minute,
+	}
+}
+
+// tokenData — данные одного кабинета за период
+type tokenData struct {
+	orders  map[int64][2]int // nmId → заказы, выкупы
+	adSpend map[int64]float64
+	storage map[int64]float64
+	adOK    bool
+	stOK    bool
+}
+
+// Calculate считает юнит-экономику всех товаров с ценами за период [from, to].
+// warehouse — склад для тарифа логистики (пусто — склад по умолчанию).
+// Недоступные источники не прерывают расчёт: соответствующие статьи попадают в Missing.
+func (s *Service) Calculate(ctx context.Context, from, to time.Time, warehouse string) ([]analytics.UnitEconomics, error) {
+	if warehouse == "" {
+		warehouse = s.warehouse
+	}
+	dateFrom, dateTo := from.Format("2006-01-02"), to.Format("2006-01-02")
+
+	costs, err := LoadCosts(s.costFile)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := s.api.GetPrices(ctx, pricesPageLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no prices loaded from WB")
+	}
+
+	commissions := map[int]float64{}
+	if tariffs, err := s.api.GetTariffs(ctx); err != nil {
+		s.logger.Warn().Err(err).Msg("⚠️ unit economics: commissions not available")
+	} else {
+		for _, t := range tariffs {
+			// paidStorageKgvp — комиссия при продаже со склада WB (FBW)
+			commissions[t.SubjectID] = t.PaidStorageKgvp
+		}
+	}
+
+	var box *api.BoxTariff
+	if tariffs, err := s.api.GetTariffsBox(ctx, dateTo); err != nil {
+		s.logger.Warn().Err(err).Msg("⚠️ unit economics: box tariffs not available")
+	} else if t, ok := analytics.FindBoxTariff(tariffs, warehouse); ok {
+		box = &t
+	} else {
+		s.logger.Warn().Msgf("⚠️ unit economics: warehouse %q not found in box tariffs", warehouse)
+	}
+
+	byToken := map[int]*tokenData{}
+	for _, p := range prices {
+		if byToken[p.TokenIdx] == nil {
+			byToken[p.TokenIdx] = s.loadTokenData(ctx, p.TokenIdx, from, to)
+		}
+	}
+
+	out := make([]analytics.UnitEconomics, 0, len(prices))
+	for _, p := range prices {
+		td := byToken[p.TokenIdx]
+		in := analytics.UnitInput{
+			NmID:        p.ID,
+			VendorCode:  p.SupplierArt,
+			TokenIdx:    p.TokenIdx,
+			Price:       p.Price,
+			DiscountPct: p.Discount,
+			ReturnCost:  analytics.DefaultReturnLogistics,
+		}
+		in.Orders, in.Buyouts = td.orders[p.ID][0], td.orders[p.ID][1]
+
+		if prod, ok := s.lookup(p.ID); ok {
+			in.Title, in.SubjectName = prod.Title, prod.SubjectName
+			if in.VendorCode == "" {
+				in.VendorCode = prod.VendorCode
+			}
+			if v, ok := commissions[prod.SubjectID]; ok {
+				in.Commission = &v
+			}
+			if box != nil && prod.VolumeLiters > 0 {
+				if v, ok := box.DeliveryCost(prod.VolumeLiters); ok {
+					in.Delivery = &v
+				}
+			}
+		}
+		if v, ok := costs.Lookup(p.ID, in.VendorCode); ok {
+			in.CostPrice = &v
+		}
+		if td.adOK {
+			v := td.adSpend[p.ID]
+			in.AdSpend = &v
+		}
+		if td.stOK {
+			v := td.storage[p.ID]
+			in.Storage = &v
+		}
+
+		out = append(out, analytics.CalcUnitEconomics(in, dateFrom, dateTo))
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].NmID < out[j].NmID })
+	return out, nil
+}
+
+func (s *Service) lookup(nmID int64) (catalog.Product, bool) {
+	if s.catalog == nil {
+		return catalog.Product{}, false
+	}
+	return s.catalog.Lookup(nmID)
+}
+
+// loadTokenData собирает заказы и выкупы, расходы на рекламу и платное хранение кабинета за период
+func (s *Service) loadTokenData(ctx context.Context, tokenIdx int, from, to time.Time) *tokenData {
+	td := &tokenData{orders: map[int64][2]int{}, adSpend: map[int64]float64{}, storage: map[int64]float64{}}
+
+	cards, err := s.api.GetNMReportDetail(ctx, tokenIdx, from.Format("2006-01-02")+" 00:00:00", to.Format("2006-01-02")+" 23:59:59")
+	if err != nil {
+		s.logger.Warn().Err(err).Msgf("⚠️ unit economics: nm-report detail incomplete (token_%d)", tokenIdx)
+	}
+	for _, card := range cards {
+		orders, buyouts := card.SelectedPeriod()
+		td.orders[card.NmID()] = [2]int{orders, buyouts}
+	}
+
+	if err := s.loadAdSpend(ctx, tokenIdx, from, to, td.adSpend); err != nil {
+		s.logger.Warn().Err(err).Msgf("⚠️ unit economics: advert stats not available (token_%d)", tokenIdx)
+	} else {
+		td.adOK = true
+	}
+
+	if err := s.loadStorage(ctx, tokenIdx, from, to, td.storage); err != nil {
+		s.logger.Warn().Err(err).Msgf("⚠️ unit economics: paid storage not available (token_%d)", tokenIdx)
+	} else {
+		td.stOK = true
+	}
+
+	s.logger.Info().Msgf("📊 unit economics data loaded (token_%d): %d cards, %d with ad spend, %d with storage",
+		tokenIdx, len(td.orders), len(td.adSpend), len(td.storage))
+	return td
+}
+
+// loadAdSpend суммирует расходы кампаний по товарам за период
+func (s *Service) loadAdSpend(ctx context.Context, tokenIdx int, from, to time.Time, out map[int64]float64) error {
+	refs, err := s.api.GetAdvertIDs(ctx, tokenIdx, advertStatuses...)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(refs))
+	for _, ref := range refs {
+		// завершённые до начала периода кампании расходов в нём не имеют
+		if ref.Status == api.AdvertStatusCompleted && ref.ChangeTime.Before(from) {
+			continue
+		}
+		ids = append(ids, ref.AdvertID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stats, err := s.api.GetAdvertFullStats(ctx, tokenIdx, ids, from, to)
+	if err != nil {
+		return err
+	}
+	for _, st := range stats {
+		for _, row := range st.ByNmDay() {
+			out[row.NmID] += row.Sum
+		}
+	}
+	return nil
+}
+
+// loadStorage получает платное хранение по товарам за период. Отчёт WB строится максимум за 8 дней,
+// поэтому для длинного периода берутся последние 8 дней и сумма пересчитывается на весь период.
+func (s *Service) loadStorage(ctx context.Context, tokenIdx int, from, to time.Time, out map[int64]float64) error {
+	stFrom := from
+	if days := int(to.Sub(from).Hours()/24) + 1; days > storageWindowDays {
+		stFrom = to.AddDate(0, 0, -(storageWindowDays - 1))
+	}
+
+	taskID, err := s.api.StartPaidStorageTask(ctx, tokenIdx, stFrom.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	if err := s.waitReport(ctx, tokenIdx, api.PaidStorageReport, taskID); err != nil {
+		return err
+	}
+	rows, err := s.api.GetPaidStorageDownload(ctx, tokenIdx, taskID)
+	if err != nil {
+		return err
+	}
+
+	scale := (to.Sub(from).Hours()/24 + 1) / (to.Sub(stFrom).Hours()/24 + 1)
+	for _, row := range rows {
+		nmID, _ := row["nmId"].(float64)
+		price, _ := row["warehousePrice"].(float64)
+		out[int64(nmID)] += price * scale
+	}
+	return nil
+}
+
+// waitReport опрашивает статус задачи асинхронного отчёта с нарастающей паузой, пока она не будет готова
+func (s *Service) waitReport(ctx context.Context, tokenIdx int, report api.AsyncReport, taskID string) error {
+	deadline := time.Now().Add(s.storageWait)
+	backoff := 5 * time.Second
+	for {
+		st, err := s.api.GetReportTaskStatus(ctx, tokenIdx, report, taskID)
+		if err == nil {
+			switch st.Data.Status {
+			case api.ReportTaskDone:
+				return nil
+			case api.ReportTaskPurged, api.ReportTaskCanceled:
+				return fmt.Errorf("%s task %s is %s", report.Name, taskID, st.Data.Status)
+			}
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("%s task %s is not ready after %s", report.Name, taskID, s.storageWait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+)
+
+// GetSupplyWarehouses godoc
+// @Summary Склады WB для поставок FBW
+// @Tags Supplies
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []api.SupplyWarehouse
+// @Failure 500 {object} map[string]string
+// @Router /api/supplies/warehouses [get]
+func (h *Handler) GetSupplyWarehouses(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetSupplyWarehouses(ctx, tokenIdx)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetSupplyWarehouses failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetAcceptanceCoefficients godoc
+// @Summary Коэффициенты приёмки складов WB
+// @Description Коэффициенты на ближайшие 14 дней: -1 — приёмка закрыта, 0 — бесплатно, N — множитель платной приёмки.
+// @Tags Supplies
+// @Param warehouse_ids query string false "ID складов через запятую"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []api.AcceptanceCoefficient
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/supplies/acceptance [get]
+func (h *Handler) GetAcceptanceCoefficients(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	ids, err := parseInt64List(r.URL.Query().Get("warehouse_ids"))
+	if err != nil {
+		http.Error(w, "invalid param: warehouse_ids", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetAcceptanceCoefficients(ctx, tokenIdx, ids)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetAcceptanceCoefficients failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetCheapestWarehouses godoc
+// @Summary Самые дешёвые склады для поставки
+// @Description Склады с открытой приёмкой в периоде: для каждого — дата с минимальным коэффициентом.
+// @Description Сортировка по коэффициенту приёмки, затем по стоимости логистики.
+// @Tags Supplies
+// @Param dateFrom query string false "Дата начала (YYYY-MM-DD), по умолчанию — сегодня"
+// @Param dateTo query string false "Дата окончания (YYYY-MM-DD), по умолчанию — через 13 дней"
+// @Param box_type query int false "Тип упаковки: 2 — короба (по умолчанию), 5 — монопаллеты, 6 — суперсейф"
+// @Param limit query int false "Сколько складов вернуть (по умолчанию 10)"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []analytics.WarehouseOption
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/supplies/cheapest [get]
+func (h *Handler) GetCheapestWarehouses(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	q := r.URL.Query()
+	from := time.Now().Format("2006-01-02")
+	to := time.Now().AddDate(0, 0, 13).Format("2006-01-02")
+	if v := q.Get("dateFrom"); v != "" {
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateFrom", http.StatusBadRequest)
+			return
+		}
+		from = v
+	}
+	if v := q.Get("dateTo"); v != "" {
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateTo", http.StatusBadRequest)
+			return
+		}
+		to = v
+	}
+	boxType := api.BoxTypeBox
+	if v := q.Get("box_type"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || (n != api.BoxTypeBox && n != api.BoxTypeMonopallet && n != api.BoxTypeSupersafe) {
+			http.Error(w, "invalid param: box_type (2, 5 or 6)", http.StatusBadRequest)
+			return
+		}
+		boxType = n
+	}
+	limit := 10
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid param: limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	tokenIdx, _ := strconv.Atoi(q.Get("token_idx"))
+
+	coefs, err := h.api.GetAcceptanceCoefficients(ctx, tokenIdx, nil)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetCheapestWarehouses failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics.CheapestWarehouses(coefs, from, to, boxType, limit))
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/advertising"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+)
+
+// GetWastedKeywords godoc
+// @Summary Ключевые фразы с расходом в дни без заказов кампании
+// @Description Ранжирует фразы с кликами по расходу в дни, когда у всей кампании не было заказов (spendNoOrders), затем по общему расходу.
+// @Description WB не отдаёт заказы в разрезе фраз, поэтому оценка — на уровне «кампания × день»: рядом с каждой фразой
+// @Description возвращаются заказы кампании в дни её расхода (campaignOrders). Без advert_id берутся все активные и приостановленные кампании.
+// @Tags Adverts
+// @Param days query int false "Период в днях (по умолчанию 7, максимум 30)"
+// @Param advert_id query int false "ID кампании"
+// @Param limit query int false "Сколько фраз вернуть (по умолчанию 50)"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/adverts/keywords/wasted [get]
+func (h *Handler) GetWastedKeywords(w http.ResponseWriter, r *http.Request) {
+	// fullstats — 1 запрос в минуту на каждые 100 кампаний
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	days := 7
+	if v := q.Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 30 {
+			http.Error(w, "invalid param: days (1..30)", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid param: limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	var advertIDs []int64
+	if v := q.Get("advert_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid param: advert_id", http.StatusBadRequest)
+			return
+		}
+		advertIDs = []int64{id}
+	}
+	tokenIdx, _ := strconv.Atoi(q.Get("token_idx"))
+
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -(days - 1))
+
+	data, err := h.advertising.WastedKeywords(ctx, tokenIdx, advertIDs, from, to, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetWastedKeywords failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// advertRequest — тело запросов на управление кампаниями
+type advertRequest struct {
+	TokenIdx int             `json:"token_idx"`
+	AdvertID int64           `json:"advert_id"`
+	Action   string          `json:"action"`
+	Sum      int             `json:"sum"`
+	Source   int             `json:"source"`
+	Mode     string          `json:"mode"`
+	Phrases  []string        `json:"phrases"`
+	Bids     []api.AdvertBid `json:"bids"`
+}
+
+// advertOpFunc выполняет операцию с кампанией и возвращает результат для ответа и журнала аудита
+type advertOpFunc func(ctx context.Context, req *advertRequest, dryRun bool) (any, error)
+
+// handleAdvertOp — общая обвязка операций с рекламой: разбор тела, dryRun, журнал аудита, ответ
+func (h *Handler) handleAdvertOp(w http.ResponseWriter, r *http.Request, action string, needAdvertID bool, do advertOpFunc) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	var req advertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if needAdvertID && req.AdvertID <= 0 {
+		http.Error(w, "missing required field: advert_id", http.StatusBadRequest)
+		return
+	}
+
+	res, err := do(ctx, &req, dryRun)
+
+	target := fmt.Sprintf("%d", req.AdvertID)
+	if !needAdvertID {
+		target = fmt.Sprintf("%d adverts", len(req.Bids))
+	}
+	entry := audit.Entry{
+		Action:   action,
+		Target:   target,
+		TokenIdx: req.TokenIdx,
+		DryRun:   dryRun,
+		Payload:  res,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.OutcomeUnknown = errors.Is(err, api.ErrOutcomeUnknown)
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("%s failed", action)
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, advertising.ErrInvalidRequest):
+			status = http.StatusBadRequest
+		case errors.Is(err, advertising.ErrNotAllowed):
+			status = http.StatusConflict
+		case errors.Is(err, api.ErrOutcomeUnknown):
+			// изменение могло примениться — повторять только после проверки состояния кампании
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// ChangeAdvertStatus godoc
+// @Summary Запустить, приостановить или завершить кампанию
+// @Description action: start, pause или stop. Перед отправкой проверяет текущий статус кампании. Требует X-API-Key.
+// @Tags Adverts
+// @Accept json
+// @Param dryRun query bool false "Только проверить, без изменений в WB"
+// @Param body body map[string]interface{} true "{advert_id, action, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/adverts/status [post]
+func (h *Handler) ChangeAdvertStatus(w http.ResponseWriter, r *http.Request) {
+	h.handleAdvertOp(w, r, "adverts.status", true, func(ctx context.Context, req *advertRequest, dryRun bool) (any, error) {
+		return h.advertising.ChangeStatus(ctx, req.TokenIdx, req.AdvertID, req.Action, dryRun)
+	})
+}
+
+// GetAdvertBudget godoc
+// @Summary Бюджет кампании
+// @Tags Adverts
+// @Param advert_id query int true "ID кампании"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/adverts/budget [get]
+func (h *Handler) GetAdvertBudget(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	advertID, err := strconv.ParseInt(r.URL.Query().Get("advert_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "missing or invalid required param: advert_id", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetAdvertBudget(ctx, tokenIdx, advertID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetAdvertBudget failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// DepositAdvertBudget godoc
+// @Summary Пополнить бюджет кампании
+// @Description source: 0 — счёт, 1 — баланс, 3 — бонусы. Требует X-API-Key.
+// @Tags Adverts
+// @Accept json
+// @Param dryRun query bool false "Только показать ожидаемый бюджет"
+// @Param body body map[string]interface{} true "{advert_id, sum, source, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/adverts/budget/deposit [post]
+func (h *Handler) DepositAdvertBudget(w http.ResponseWriter, r *http.Request) {
+	h.handleAdvertOp(w, r, "adverts.budget.deposit", true, func(ctx context.Context, req *advertRequest, dryRun bool) (any, error) {
+		return h.advertising.Deposit(ctx, req.TokenIdx, req.AdvertID, req.Sum, req.Source, dryRun)
+	})
+}
+
+// SetAdvertBids godoc
+// @Summary Изменить ставки CPM
+// @Description Требует X-API-Key.
+// @Tags Adverts
+// @Accept json
+// @Param dryRun query bool false "Только проверить запрос"
+// @Param body body map[string]interface{} true "{token_idx, bids: [{advert_id, nm_bids: [{nm, bid}]}]}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/adverts/bids [post]
+func (h *Handler) SetAdvertBids(w http.ResponseWriter, r *http.Request) {
+	h.handleAdvertOp(w, r, "adverts.bids", false, func(ctx context.Context, req *advertRequest, dryRun bool) (any, error) {
+		return h.advertising.SetBids(ctx, req.TokenIdx, req.Bids, dryRun)
+	})
+}
+
+// SetAdvertMinusPhrases godoc
+// @Summary Минус-фразы кампании
+// @Description mode: add (по умолчанию), remove или replace. Возвращает списки до и после изменения. Требует X-API-Key.
+// @Tags Adverts
+// @Accept json
+// @Param dryRun query bool false "Только показать изменения"
+// @Param body body map[string]interface{} true "{advert_id, mode, phrases, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/adverts/minus-phrases [post]
+func (h *Handler) SetAdvertMinusPhrases(w http.ResponseWriter, r *http.Request) {
+	h.handleAdvertOp(w, r, "adverts.minus_phrases", true, func(ctx context.Context, req *advertRequest, dryRun bool) (any, error) {
+		return h.advertising.SetMinusPhrases(ctx, req.TokenIdx, req.AdvertID, req.Mode, req.Phrases, dryRun)
+	})
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/answers"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+)
+
+// replyRequest — тело запросов на ответ / редактирование / просмотр
+type replyRequest struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	TokenIdx int    `json:"token_idx"`
+}
+
+// replyResponse — результат операции записи
+type replyResponse struct {
+	Status   string `json:"status"`
+	ID       string `json:"id"`
+	Text     string `json:"text,omitempty"`
+	Template bool   `json:"template,omitempty"`
+}
+
+// replyFunc выполняет операцию в WB и возвращает итоговый текст ответа
+type replyFunc func(ctx context.Context, req *replyRequest) (text string, templated bool, err error)
+
+// handleReply — общая обвязка: разбор тела, вызов WB, журнал аудита, ответ.
+// templateKind — вид шаблона, которым можно заменить пустой text; "" — операции текст не нужен.
+func (h *Handler) handleReply(w http.ResponseWriter, r *http.Request, action, templateKind string, do replyFunc) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	var req replyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		http.Error(w, "missing required field: id", http.StatusBadRequest)
+		return
+	}
+	// без шаблонов этого вида пустой text заведомо не подставить — не ходим в WB за отзывом/вопросом
+	if templateKind != "" && req.Text == "" && !h.templates.HasKind(templateKind) {
+		http.Error(w, "missing required field: text", http.StatusBadRequest)
+		return
+	}
+
+	text, templated, err := do(ctx, &req)
+
+	entry := audit.Entry{
+		Action:   action,
+		Target:   req.ID,
+		TokenIdx: req.TokenIdx,
+		Payload:  map[string]any{"text": text, "template": templated},
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.OutcomeUnknown = errors.Is(err, api.ErrOutcomeUnknown)
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("%s failed (id=%s)", action, req.ID)
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, answers.ErrNoTemplate):
+			status = http.StatusBadRequest
+		case errors.Is(err, api.ErrOutcomeUnknown):
+			// ответ мог быть опубликован — повторять только после проверки отзыва/вопроса
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(replyResponse{Status: "ok", ID: req.ID, Text: text, Template: templated})
+}
+
+// feedbackText возвращает текст из запроса или рендерит шаблон по nmId и оценке отзыва
+func (h *Handler) feedbackText(ctx context.Context, req *replyRequest) (string, bool, error) {
+	if req.Text != "" {
+		return req.Text, false, nil
+	}
+	f, err := h.api.GetFeedback(ctx, req.TokenIdx, req.ID)
+	if err != nil {
+		return "", false, err
+	}
+	text, err := h.templates.Render(answers.KindFeedback, f.ProductDetails.NmID, f.ProductValuation, answers.Data{
+		UserName:    f.UserName,
+		ProductName: f.ProductDetails.ProductName,
+		Brand:       f.ProductDetails.BrandName,
+		Rating:      f.ProductValuation,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return text, true, nil
+}
+
+// questionText возвращает текст из запроса или рендерит шаблон по nmId вопроса
+func (h *Handler) questionText(ctx context.Context, req *replyRequest) (string, bool, error) {
+	if req.Text != "" {
+		return req.Text, false, nil
+	}
+	q, err := h.api.GetQuestion(ctx, req.TokenIdx, req.ID)
+	if err != nil {
+		return "", false, err
+	}
+	text, err := h.templates.Render(answers.KindQuestion, q.ProductDetails.NmID, 0, answers.Data{
+		ProductName: q.ProductDetails.ProductName,
+		Brand:       q.ProductDetails.BrandName,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return text, true, nil
+}
+
+// AnswerFeedback godoc
+// @Summary Ответить на отзыв
+// @Description Отправляет ответ на отзыв. Если text пуст — текст подбирается по шаблону (nmId + оценка). Требует X-API-Key.
+// @Tags Feedbacks
+// @Accept json
+// @Param body body map[string]interface{} true "{id, text, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/feedbacks/answer [post]
+func (h *Handler) AnswerFeedback(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "feedback.answer", answers.KindFeedback, func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		text, templated, err := h.feedbackText(ctx, req)
+		if err != nil {
+			return "", false, err
+		}
+		return text, templated, h.api.AnswerFeedback(ctx, req.TokenIdx, req.ID, text)
+	})
+}
+
+// EditFeedbackAnswer godoc
+// @Summary Изменить ответ на отзыв
+// @Description Редактирует ранее отправленный ответ на отзыв. Требует X-API-Key.
+// @Tags Feedbacks
+// @Accept json
+// @Param body body map[string]interface{} true "{id, text, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/feedbacks/answer [patch]
+func (h *Handler) EditFeedbackAnswer(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "feedback.edit_answer", answers.KindFeedback, func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		text, templated, err := h.feedbackText(ctx, req)
+		if err != nil {
+			return "", false, err
+		}
+		return text, templated, h.api.EditFeedbackAnswer(ctx, req.TokenIdx, req.ID, text)
+	})
+}
+
+// MarkFeedbackViewed godoc
+// @Summary Отметить отзыв просмотренным
+// @Description Требует X-API-Key.
+// @Tags Feedbacks
+// @Accept json
+// @Param body body map[string]interface{} true "{id, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/feedbacks/viewed [post]
+func (h *Handler) MarkFeedbackViewed(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "feedback.viewed", "", func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		return "", false, h.api.MarkFeedbackViewed(ctx, req.TokenIdx, req.ID)
+	})
+}
+
+// AnswerQuestion godoc
+// @Summary Ответить на вопрос
+// @Description Отправляет ответ на вопрос покупателя. Если text пуст — текст подбирается по шаблону (nmId). Требует X-API-Key.
+// @Tags Questions
+// @Accept json
+// @Param body body map[string]interface{} true "{id, text, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/questions/answer [post]
+func (h *Handler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "question.answer", answers.KindQuestion, func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		text, templated, err := h.questionText(ctx, req)
+		if err != nil {
+			return "", false, err
+		}
+		return text, templated, h.api.AnswerQuestion(ctx, req.TokenIdx, req.ID, text)
+	})
+}
+
+// EditQuestionAnswer godoc
+// @Summary Изменить ответ на вопрос
+// @Description Требует X-API-Key.
+// @Tags Questions
+// @Accept json
+// @Param body body map[string]interface{} true "{id, text, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/questions/answer [patch]
+func (h *Handler) EditQuestionAnswer(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "question.edit_answer", answers.KindQuestion, func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		text, templated, err := h.questionText(ctx, req)
+		if err != nil {
+			return "", false, err
+		}
+		return text, templated, h.api.EditQuestionAnswer(ctx, req.TokenIdx, req.ID, text)
+	})
+}
+
+// MarkQuestionViewed godoc
+// @Summary Отметить вопрос просмотренным
+// @Description Требует X-API-Key.
+// @Tags Questions
+// @Accept json
+// @Param body body map[string]interface{} true "{id, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/questions/viewed [post]
+func (h *Handler) MarkQuestionViewed(w http.ResponseWriter, r *http.Request) {
+	h.handleReply(w, r, "question.viewed", "", func(ctx context.Context, req *replyRequest) (string, bool, error) {
+		return "", false, h.api.MarkQuestionViewed(ctx, req.TokenIdx, req.ID)
+	})
+}
+
+// GetAnswerTemplates godoc
+// @Summary Шаблоны ответов
+// @Description Возвращает загруженные шаблоны ответов на отзывы и вопросы. Требует X-API-Key.
+// @Tags Feedbacks
+// @Success 200 {object} []map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Router /api/answers/templates [get]
+func (h *Handler) GetAnswerTemplates(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.templates.List())
+}
+
+// GetAudit godoc
+// @Summary Журнал аудита
+// @Description Возвращает записи журнала: кто, что и когда отправил в WB через сервис. Требует X-API-Key.
+// @Tags Audit
+// @Param action query string false "Действие, например feedback.answer"
+// @Param user query string false "Пользователь"
+// @Param since query string false "Дата начала (YYYY-MM-DD)"
+// @Param limit query int false "Максимум записей (последние)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/audit [get]
+func (h *Handler) GetAudit(w http.ResponseWriter, r *http.Request) {
+	if h.audit == nil {
+		http.Error(w, "audit log is not configured", http.StatusNotFound)
+		return
+	}
+
+	flt := audit.Filter{
+		Action: r.URL.Query().Get("action"),
+		User:   r.URL.Query().Get("user"),
+	}
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			http.Error(w, "invalid param since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		flt.Since = t
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "invalid param limit: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		flt.Limit = limit
+	}
+
+	data, err := h.audit.List(flt)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetAudit failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// reportTaskParams читает taskId и token_idx задачи асинхронного отчёта. Оба обязательны:
+// задача существует только у токена, который её создал, и подставлять первый токен нельзя.
+func reportTaskParams(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	taskID := r.URL.Query().Get("taskId")
+	if taskID == "" {
+		http.Error(w, "missing required param: taskId", http.StatusBadRequest)
+		return 0, "", false
+	}
+	tokenIdx, err := strconv.Atoi(r.URL.Query().Get("token_idx"))
+	if err != nil || tokenIdx < 1 {
+		http.Error(w, "missing or invalid required param: token_idx", http.StatusBadRequest)
+		return 0, "", false
+	}
+	return tokenIdx, taskID, true
+}
+
+// StartWarehouseRemains godoc
+// @Summary Создать отчёт об остатках на складах WB
+// @Description Создаёт задание на генерацию отчёта об остатках (снимок на текущий момент) по каждому токену. Требует X-API-Key.
+// @Tags Async Reports
+// @Success 200 {object} []map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Router /api/warehouse_remains/start [get]
+func (h *Handler) StartWarehouseRemains(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	data := h.api.StartWarehouseRemains(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetWarehouseRemainsStatus godoc
+// @Summary Статус отчёта об остатках на складах WB
+// @Tags Async Reports
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/warehouse_remains/status [get]
+func (h *Handler) GetWarehouseRemainsStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	tokenIdx, taskID, ok := reportTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.api.GetWarehouseRemainsStatus(ctx, tokenIdx, taskID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetWarehouseRemainsStatus failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetWarehouseRemainsDownload godoc
+// @Summary Скачать отчёт об остатках на складах WB
+// @Tags Async Reports
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
+// @Success 200 {object} []api.WarehouseRemainsRow
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/warehouse_remains/download [get]
+func (h *Handler) GetWarehouseRemainsDownload(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	tokenIdx, taskID, ok := reportTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.api.GetWarehouseRemainsDownload(ctx, tokenIdx, taskID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetWarehouseRemainsDownload failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// StartAcceptanceReport godoc
+// @Summary Создать отчёт о платной приёмке
+// @Description Создаёт задание на генерацию отчёта о платной приёмке по каждому токену. Период — максимум 31 день. Требует X-API-Key.
+// @Tags Async Reports
+// @Param dateFrom query string true "Дата начала (YYYY-MM-DD)"
+// @Param dateTo query string true "Дата окончания (YYYY-MM-DD)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/acceptance_report/start [get]
+func (h *Handler) StartAcceptanceReport(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	dateFrom := r.URL.Query().Get("dateFrom")
+	dateTo := r.URL.Query().Get("dateTo")
+	if dateFrom == "" || dateTo == "" {
+		http.Error(w, "missing required params: dateFrom, dateTo", http.StatusBadRequest)
+		return
+	}
+
+	data := h.api.StartAcceptanceReport(ctx, dateFrom, dateTo)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetAcceptanceReportStatus godoc
+// @Summary Статус отчёта о платной приёмке
+// @Tags Async Reports
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/acceptance_report/status [get]
+func (h *Handler) GetAcceptanceReportStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	tokenIdx, taskID, ok := reportTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.api.GetAcceptanceReportStatus(ctx, tokenIdx, taskID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetAcceptanceReportStatus failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetAcceptanceReportDownload godoc
+// @Summary Скачать отчёт о платной приёмке
+// @Tags Async Reports
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
+// @Success 200 {object} []api.AcceptanceReportRow
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/acceptance_report/download [get]
+func (h *Handler) GetAcceptanceReportDownload(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	tokenIdx, taskID, ok := reportTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.api.GetAcceptanceReportDownload(ctx, tokenIdx, taskID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetAcceptanceReportDownload failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetMeasurementPenalties godoc
+// @Summary Удержания за занижение габаритов упаковки
+// @Description Отчёт WB синхронный: отдаётся сразу, без задачи и опроса статуса. Без token_idx — по всем токенам.
+// @Tags Async Reports
+// @Param dateFrom query string true "Начало периода, YYYY-MM-DD"
+// @Param dateTo query string false "Конец периода, YYYY-MM-DD (по умолчанию сегодня)"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []api.MeasurementPenalty
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/measurement_penalties [get]
+func (h *Handler) GetMeasurementPenalties(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	from, err := time.ParseInLocation("2006-01-02", r.URL.Query().Get("dateFrom"), time.Local)
+	if err != nil {
+		http.Error(w, "missing or invalid required param: dateFrom (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	to := time.Now()
+	if v := r.URL.Query().Get("dateTo"); v != "" {
+		d, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			http.Error(w, "invalid param: dateTo (YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		// dateTo включительно — до конца дня
+		to = d.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	var data []api.MeasurementPenalty
+	if v := r.URL.Query().Get("token_idx"); v != "" {
+		tokenIdx, _ := strconv.Atoi(v)
+		data, err = h.api.GetMeasurementPenalties(ctx, tokenIdx, from, to)
+	} else {
+		data, err = h.api.GetAllMeasurementPenalties(ctx, from, to)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetMeasurementPenalties failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
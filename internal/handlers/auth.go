@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"wildberriesapi/internal/audit"
+)
+
+type ctxKey string
+
+const userCtxKey ctxKey = "user"
+
+// RequireAPIKey — middleware для операций записи: ключ берётся из X-API-Key
+// или Authorization: Bearer <key>. Без настроенных ключей доступ закрыт.
+func RequireAPIKey(keys map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("X-API-Key")
+			if key == "" {
+				key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			}
+			if key == "" {
+				http.Error(w, "missing API key", http.StatusUnauthorized)
+				return
+			}
+
+			for k, user := range keys {
+				if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+					ctx := context.WithValue(r.Context(), userCtxKey, user)
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+			}
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+		})
+	}
+}
+
+// userFromContext возвращает имя пользователя, прошедшего RequireAPIKey
+func userFromContext(ctx context.Context) string {
+	if u, ok := ctx.Value(userCtxKey).(string); ok {
+		return u
+	}
+	return ""
+}
+
+// recordAudit пишет запись в журнал аудита (если он подключён)
+func (h *Handler) recordAudit(r *http.Request, e audit.Entry) {
+	if h.audit == nil {
+		return
+	}
+	e.User = userFromContext(r.Context())
+	if err := h.audit.Record(e); err != nil {
+		h.logger.Error().Err(err).Msgf("❌ failed to write audit entry %s target=%s", e.Action, e.Target)
+	}
+}
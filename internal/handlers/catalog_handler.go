@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// GetCatalog godoc
+// @Summary Каталог товаров
+// @Description Возвращает локальный каталог карточек (название, артикул продавца, бренд, предмет, габариты).
+// @Description С nmId или barcode — один товар.
+// @Tags Catalog
+// @Param nmId query int false "Артикул WB"
+// @Param barcode query string false "Баркод"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/catalog [get]
+func (h *Handler) GetCatalog(w http.ResponseWriter, r *http.Request) {
+	if h.catalog == nil {
+		http.Error(w, "catalogue is not configured", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if v := r.URL.Query().Get("nmId"); v != "" {
+		nmID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid param nmId: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		p, ok := h.catalog.Lookup(nmID)
+		if !ok {
+			http.Error(w, "product not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(p)
+		return
+	}
+
+	if v := r.URL.Query().Get("barcode"); v != "" {
+		p, ok := h.catalog.LookupBarcode(v)
+		if !ok {
+			http.Error(w, "product not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(p)
+		return
+	}
+
+	json.NewEncoder(w).Encode(h.catalog.List())
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GetUnitEconomics godoc
+// @Summary Юнит-экономика товаров
+// @Description Прибыль с одного выкупа по каждому nmId: цена со скидкой минус комиссия WB, логистика
+// @Description (с учётом невыкупов), платное хранение, реклама и себестоимость из файла COST_FILE.
+// @Description Статьи без данных считаются как 0 и перечисляются в missing. Расчёт занимает несколько минут,
+// @Description создаёт отчёт о платном хранении и расходует лимит статистики рекламы, поэтому требует X-API-Key.
+// @Description Готовый ежедневный расчёт публикуется в топик wb.raw.unit_economics.
+// @Tags Economics
+// @Param dateFrom query string false "Дата начала (YYYY-MM-DD), по умолчанию — 7 дней назад"
+// @Param dateTo query string false "Дата окончания (YYYY-MM-DD), по умолчанию — вчера"
+// @Param warehouse query string false "Склад для тарифа логистики, по умолчанию — UNIT_ECONOMICS_WAREHOUSE"
+// @Param nmId query int false "Артикул WB (один товар)"
+// @Success 200 {object} []analytics.UnitEconomics
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/economics/units [get]
+func (h *Handler) GetUnitEconomics(w http.ResponseWriter, r *http.Request) {
+	if h.economics == nil {
+		http.Error(w, "unit economics is not configured", http.StatusNotFound)
+		return
+	}
+
+	// отчёт о хранении строится асинхронно, статистика рекламы — с лимитом 1 запрос в минуту
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -6)
+	var err error
+	if v := q.Get("dateFrom"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateFrom", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("dateTo"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateTo", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "dateTo is before dateFrom", http.StatusBadRequest)
+		return
+	}
+	var nmID int64
+	if v := q.Get("nmId"); v != "" {
+		if nmID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, "invalid param: nmId", http.StatusBadRequest)
+			return
+		}
+	}
+
+	data, err := h.economics.Calculate(ctx, from, to, q.Get("warehouse"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetUnitEconomics failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if nmID != 0 {
+		for _, u := range data {
+			if u.NmID == nmID {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(u)
+				return
+			}
+		}
+		http.Error(w, "product not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
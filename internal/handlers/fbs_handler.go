@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+)
+
+// parseInt64List разбирает список ID вида "1,2,3"
+func parseInt64List(s string) ([]int64, error) {
+	out := make([]int64, 0)
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// GetNewFBSOrders godoc
+// @Summary Новые сборочные задания FBS
+// @Description Возвращает новые сборочные задания по всем токенам
+// @Tags FBS
+// @Success 200 {object} []map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/orders/new [get]
+func (h *Handler) GetNewFBSOrders(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	data, err := h.api.GetNewFBSOrders(ctx)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetNewFBSOrders failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetFBSOrderStatuses godoc
+// @Summary Статусы сборочных заданий FBS
+// @Tags FBS
+// @Param ids query string true "ID заданий через запятую"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/orders/status [get]
+func (h *Handler) GetFBSOrderStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	ids, err := parseInt64List(r.URL.Query().Get("ids"))
+	if err != nil || len(ids) == 0 {
+		http.Error(w, "missing or invalid required param: ids", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetFBSOrderStatuses(ctx, tokenIdx, ids)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFBSOrderStatuses failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetFBSSupplies godoc
+// @Summary Поставки FBS
+// @Tags FBS
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/supplies [get]
+func (h *Handler) GetFBSSupplies(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetFBSSupplies(ctx, tokenIdx)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFBSSupplies failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetFBSStickers godoc
+// @Summary Этикетки сборочных заданий FBS
+// @Tags FBS
+// @Param ids query string true "ID заданий через запятую"
+// @Param type query string false "svg, png, zplv, zplh (по умолчанию svg)"
+// @Param size query string false "58x40 или 40x30 (по умолчанию 58x40)"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/stickers [get]
+func (h *Handler) GetFBSStickers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	ids, err := parseInt64List(r.URL.Query().Get("ids"))
+	if err != nil || len(ids) == 0 {
+		http.Error(w, "missing or invalid required param: ids", http.StatusBadRequest)
+		return
+	}
+	stickerType := r.URL.Query().Get("type")
+	if stickerType == "" {
+		stickerType = "svg"
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetFBSStickers(ctx, tokenIdx, ids, stickerType, r.URL.Query().Get("size"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFBSStickers failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// fbsSupplyRequest — тело запросов на операции с поставками
+type fbsSupplyRequest struct {
+	TokenIdx int     `json:"token_idx"`
+	Name     string  `json:"name"`
+	SupplyID string  `json:"supply_id"`
+	OrderIDs []int64 `json:"order_ids"`
+}
+
+// CreateFBSSupply godoc
+// @Summary Создать поставку FBS
+// @Description Требует X-API-Key.
+// @Tags FBS
+// @Accept json
+// @Param body body map[string]interface{} true "{name, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/fbs/supplies [post]
+func (h *Handler) CreateFBSSupply(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	var req fbsSupplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "missing required field: name", http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.api.CreateFBSSupply(ctx, req.TokenIdx, req.Name)
+	entry := audit.Entry{Action: "fbs.supply.create", Target: id, TokenIdx: req.TokenIdx, Payload: req}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.OutcomeUnknown = errors.Is(err, api.ErrOutcomeUnknown)
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		http.Error(w, err.Error(), writeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"id": id})
+}
+
+// AddOrdersToFBSSupply godoc
+// @Summary Добавить сборочные задания в поставку FBS
+// @Description Возвращает ошибки по каждому заданию, которое не удалось добавить. Требует X-API-Key.
+// @Tags FBS
+// @Accept json
+// @Param body body map[string]interface{} true "{supply_id, order_ids, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/fbs/supplies/orders [post]
+func (h *Handler) AddOrdersToFBSSupply(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	var req fbsSupplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SupplyID == "" || len(req.OrderIDs) == 0 {
+		http.Error(w, "missing required fields: supply_id, order_ids", http.StatusBadRequest)
+		return
+	}
+
+	added := make([]int64, 0, len(req.OrderIDs))
+	failed := map[int64]string{}
+	// unknown — задания, по которым WB не подтвердил результат: они могли попасть в поставку
+	unknown := make([]int64, 0)
+	for _, id := range req.OrderIDs {
+		if err := h.api.AddOrderToFBSSupply(ctx, req.TokenIdx, req.SupplyID, id); err != nil {
+			failed[id] = err.Error()
+			if errors.Is(err, api.ErrOutcomeUnknown) {
+				unknown = append(unknown, id)
+			}
+			continue
+		}
+		added = append(added, id)
+	}
+
+	h.recordAudit(r, audit.Entry{
+		Action:         "fbs.supply.add_orders",
+		Target:         req.SupplyID,
+		TokenIdx:       req.TokenIdx,
+		Payload:        map[string]any{"added": added, "failed": failed, "unknown": unknown},
+		OutcomeUnknown: len(unknown) > 0,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"supply_id": req.SupplyID, "added": added, "failed": failed, "unknown": unknown})
+}
+
+// DeliverFBSSupply godoc
+// @Summary Передать поставку FBS в доставку
+// @Description Требует X-API-Key.
+// @Tags FBS
+// @Accept json
+// @Param body body map[string]interface{} true "{supply_id, token_idx}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string "WB не подтвердил результат — проверьте состояние перед повтором"
+// @Router /api/fbs/supplies/deliver [post]
+func (h *Handler) DeliverFBSSupply(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	var req fbsSupplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SupplyID == "" {
+		http.Error(w, "missing required field: supply_id", http.StatusBadRequest)
+		return
+	}
+
+	err := h.api.DeliverFBSSupply(ctx, req.TokenIdx, req.SupplyID)
+	entry := audit.Entry{Action: "fbs.supply.deliver", Target: req.SupplyID, TokenIdx: req.TokenIdx}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.OutcomeUnknown = errors.Is(err, api.ErrOutcomeUnknown)
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		http.Error(w, err.Error(), writeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok", "supply_id": req.SupplyID})
+}
+
+// writeErrorStatus — HTTP-статус ошибки изменяющего запроса к WB: 504, если результат неизвестен, иначе 502
+func writeErrorStatus(err error) int {
+	if errors.Is(err, api.ErrOutcomeUnknown) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
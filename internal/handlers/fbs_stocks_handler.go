@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/audit"
+	"wildberriesapi/internal/stocksync"
+)
+
+// GetFBSWarehouses godoc
+// @Summary Склады продавца (FBS)
+// @Tags FBS
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/warehouses [get]
+func (h *Handler) GetFBSWarehouses(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetFBSWarehouses(ctx, tokenIdx)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFBSWarehouses failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetFBSStocks godoc
+// @Summary Остатки на складе продавца (FBS)
+// @Tags FBS
+// @Param warehouse_id query int true "ID склада продавца"
+// @Param skus query string true "SKU (баркоды) через запятую"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/stocks [get]
+func (h *Handler) GetFBSStocks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	warehouseID, err := strconv.ParseInt(r.URL.Query().Get("warehouse_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "missing or invalid required param: warehouse_id", http.StatusBadRequest)
+		return
+	}
+	skus := make([]string, 0)
+	for _, s := range strings.Split(r.URL.Query().Get("skus"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			skus = append(skus, s)
+		}
+	}
+	if len(skus) == 0 {
+		http.Error(w, "missing required param: skus", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	data, err := h.api.GetFBSStocks(ctx, tokenIdx, warehouseID, skus)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFBSStocks failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// SyncFBSStocks godoc
+// @Summary Синхронизировать остатки складов продавца с WB
+// @Description Принимает фид остатков (JSON-массив [{warehouse_id, sku, amount}] или CSV с заголовком warehouse_id,sku,amount),
+// @Description сравнивает с остатками в WB и отправляет только расхождения. С dryRun=true только возвращает расхождения. Требует X-API-Key.
+// @Tags FBS
+// @Accept json
+// @Accept text/csv
+// @Param dryRun query bool false "Только показать расхождения"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/fbs/stocks/sync [post]
+func (h *Handler) SyncFBSStocks(w http.ResponseWriter, r *http.Request) {
+	if h.stockSync == nil {
+		http.Error(w, "stock sync is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	var (
+		items []stocksync.Item
+		err   error
+	)
+	if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+		items, err = stocksync.ParseCSV(r.Body)
+	} else {
+		items, err = stocksync.ParseJSON(r.Body)
+	}
+	if err == nil {
+		err = stocksync.Validate(items)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rep, err := h.stockSync.Reconcile(ctx, tokenIdx, items, dryRun)
+
+	entry := audit.Entry{
+		Action:   "fbs.stocks.sync",
+		Target:   fmt.Sprintf("%d items", len(items)),
+		TokenIdx: tokenIdx,
+		DryRun:   dryRun,
+		Payload:  rep,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		h.logger.Error().Err(err).Msg("SyncFBSStocks failed")
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rep)
+}
+
+// GetLastFBSStockSync godoc
+// @Summary Отчёт последней синхронизации остатков FBS
+// @Tags FBS
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/fbs/stocks/sync/last [get]
+func (h *Handler) GetLastFBSStockSync(w http.ResponseWriter, r *http.Request) {
+	if h.stockSync == nil {
+		http.Error(w, "stock sync is not configured", http.StatusNotFound)
+		return
+	}
+
+	rep, err := h.stockSync.LastReport()
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetLastFBSStockSync failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rep == nil {
+		http.Error(w, "no stock sync has run yet", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rep)
+}
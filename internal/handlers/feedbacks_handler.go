@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// parseFeedbackQuery разбирает общие query-параметры для отзывов и вопросов
+func parseFeedbackQuery(r *http.Request) (api.FeedbackQuery, error) {
+	q := api.FeedbackQuery{IsAnswered: false}
+
+	if v := r.URL.Query().Get("nmId"); v != "" {
+		nmID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return q, err
+		}
+		q.NmID = nmID
+	}
+	if v := r.URL.Query().Get("dateFrom"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return q, err
+		}
+		q.DateFrom = t
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return q, err
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
+
+// GetUnansweredFeedbacks godoc
+// @Summary Получить неотвеченные отзывы из WB API
+// @Description Возвращает отзывы без ответа продавца по всем токенам
+// @Tags Feedbacks
+// @Param nmId query int false "Артикул WB"
+// @Param dateFrom query string false "Дата начала (YYYY-MM-DD)"
+// @Param limit query int false "Максимум записей на токен"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/feedbacks/unanswered [get]
+func (h *Handler) GetUnansweredFeedbacks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	q, err := parseFeedbackQuery(r)
+	if err != nil {
+		http.Error(w, "invalid query params: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.api.GetFeedbacks(ctx, q)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetFeedbacks failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetUnansweredQuestions godoc
+// @Summary Получить неотвеченные вопросы из WB API
+// @Description Возвращает вопросы покупателей без ответа продавца по всем токенам
+// @Tags Questions
+// @Param nmId query int false "Артикул WB"
+// @Param dateFrom query string false "Дата начала (YYYY-MM-DD)"
+// @Param limit query int false "Максимум записей на токен"
+// @Success 200 {object} []map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/unanswered [get]
+func (h *Handler) GetUnansweredQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	q, err := parseFeedbackQuery(r)
+	if err != nil {
+		http.Error(w, "invalid query params: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.api.GetQuestions(ctx, q)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetQuestions failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
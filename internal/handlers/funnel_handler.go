@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/api"
+)
+
+// GetGroupedFunnel godoc
+// @Summary Воронка продаж по предметам, брендам или ярлыкам
+// @Description Конверсии просмотр → корзина → заказ → выкуп по группам за период.
+// @Description С daily=true — по дням (период не больше 7 дней, история WB доступна только за последнюю неделю).
+// @Tags Funnel
+// @Param by query string true "Группировка: subject, brand или tag"
+// @Param dateFrom query string false "Дата начала (YYYY-MM-DD), по умолчанию — 7 дней назад"
+// @Param dateTo query string false "Дата окончания (YYYY-MM-DD), по умолчанию — вчера"
+// @Param daily query bool false "Разбивка по дням"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} []analytics.FunnelRow
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/funnel/grouped [get]
+func (h *Handler) GetGroupedFunnel(w http.ResponseWriter, r *http.Request) {
+	// grouped листается постранично с лимитом 3 запроса в минуту
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	by := q.Get("by")
+	if err := analytics.ValidGroupBy(by); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -6)
+	var err error
+	if v := q.Get("dateFrom"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateFrom", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("dateTo"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "invalid param: dateTo", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "dateTo is before dateFrom", http.StatusBadRequest)
+		return
+	}
+
+	daily := false
+	if v := q.Get("daily"); v != "" {
+		if daily, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "invalid param: daily", http.StatusBadRequest)
+			return
+		}
+	}
+	if daily && to.Sub(from) > 6*24*time.Hour {
+		http.Error(w, "daily funnel is limited to 7 days", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(q.Get("token_idx"))
+
+	var stats []api.NMGroupStats
+	if daily {
+		stats, err = h.api.GetNMReportGroupedHistory(ctx, tokenIdx, api.NMGroupFilter{}, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	} else {
+		stats, err = h.api.GetNMReportGrouped(ctx, tokenIdx, api.NMGroupFilter{}, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetGroupedFunnel failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics.GroupFunnel(stats, by, daily))
+}
@@ -2,17 +2,65 @@ package handlers
 
 import (
 	"github.com/rs/zerolog"
+	"wildberriesapi/internal/advertising"
+	"wildberriesapi/internal/answers"
 	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+	"wildberriesapi/internal/catalog"
+	"wildberriesapi/internal/economics"
+	"wildberriesapi/internal/pricing"
+	"wildberriesapi/internal/stocksync"
 )
 
 type Handler struct {
-	api    *api.WBClient
-	logger zerolog.Logger
+	api         *api.WBClient
+	logger      zerolog.Logger
+	audit       audit.Logger
+	templates   *answers.Templates
+	catalog     *catalog.Catalog
+	pricing     *pricing.Service
+	stockSync   *stocksync.Service
+	advertising *advertising.Service
+	economics   *economics.Service
 }
 
-func NewHandler(api *api.WBClient, logger zerolog.Logger) *Handler {
-	return &Handler{
-		api:    api,
-		logger: logger,
+// Option — необязательная зависимость Handler
+type Option func(h *Handler)
+
+// WithAudit подключает журнал аудита для операций записи
+func WithAudit(a audit.Logger) Option {
+	return func(h *Handler) { h.audit = a }
+}
+
+// WithTemplates подключает шаблоны ответов на отзывы и вопросы
+func WithTemplates(t *answers.Templates) Option {
+	return func(h *Handler) { h.templates = t }
+}
+
+// WithCatalog подключает локальный каталог товаров
+func WithCatalog(c *catalog.Catalog) Option {
+	return func(h *Handler) { h.catalog = c }
+}
+
+// WithStockSync подключает сверку остатков складов продавца с WB
+func WithStockSync(s *stocksync.Service) Option {
+	return func(h *Handler) { h.stockSync = s }
+}
+
+// WithEconomics подключает расчёт юнит-экономики
+func WithEconomics(e *economics.Service) Option {
+	return func(h *Handler) { h.economics = e }
+}
+
+func NewHandler(api *api.WBClient, logger zerolog.Logger, opts ...Option) *Handler {
+	h := &Handler{
+		api:         api,
+		logger:      logger,
+		pricing:     pricing.NewService(api),
+		advertising: advertising.NewService(api, logger),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
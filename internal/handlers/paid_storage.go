@@ -11,13 +11,15 @@ import (
 // @Summary Создать отчёт из WB API
 // @Description Метод создаёт задание на генерацию отчёта о платном хранении.
 //
-//	Можно получить отчёт максимум за 8 дней.
+//	Можно получить отчёт максимум за 8 дней. Задача создаётся для каждого токена;
+//	в ответе — token_idx и task_id, оба нужны для статуса и скачивания. Требует X-API-Key.
 //
 // @Tags Paid Storage
 // @Param dateFrom query string true "Дата начала (YYYY-MM-DD)"
-// @Param dateTo query string false "Дата окончания (YYYY-MM-DD)"
+// @Param dateTo query string true "Дата окончания (YYYY-MM-DD)"
 // @Success 200 {object} []map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/paid_storage/start [get]
 func (h *Handler) StartPaidStorage(w http.ResponseWriter, r *http.Request) {
@@ -31,7 +33,7 @@ func (h *Handler) StartPaidStorage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if dateTo == "" {
-		http.Error(w, "missing required param: dateFrom", http.StatusBadRequest)
+		http.Error(w, "missing required param: dateTo", http.StatusBadRequest)
 		return
 	}
 
@@ -50,6 +52,8 @@ func (h *Handler) StartPaidStorage(w http.ResponseWriter, r *http.Request) {
 // @Summary Проверить статус из WB API
 // @Description Возвращает статус задания на генерацию отчёта о платном хранении заказов за указанный период
 // @Tags Paid Storage
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
 // @Success 200 {object} []map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -58,16 +62,14 @@ func (h *Handler) GetPaidStorageStatus(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
 	defer cancel()
 
-	taskId := r.URL.Query().Get("taskId")
-	if taskId == "" {
-		h.logger.Error().Msg("missing required param: taskId")
-		http.Error(w, "missing required param: taskId", http.StatusBadRequest)
+	tokenIdx, taskId, ok := reportTaskParams(w, r)
+	if !ok {
 		return
 	}
 
-	data, err := h.api.GetPaidStorageStatus(ctx, taskId)
+	data, err := h.api.GetPaidStorageStatus(ctx, tokenIdx, taskId)
 	if err != nil {
-		h.logger.Error().Err(err).Msg("StartPaidStorage failed")
+		h.logger.Error().Err(err).Msg("GetPaidStorageStatus failed")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -80,8 +82,8 @@ func (h *Handler) GetPaidStorageStatus(w http.ResponseWriter, r *http.Request) {
 // @Summary Получить отчёт из WB API
 // @Description Метод возвращает отчёт о платном хранении по ID задания на генерацию.
 // @Tags Paid Storage
-// @Param dateFrom query string true "Дата начала (YYYY-MM-DD)"
-// @Param dateTo query string false "Дата окончания (YYYY-MM-DD)"
+// @Param taskId query string true "ID задания"
+// @Param token_idx query int true "Номер токена, создавшего задание (с 1)"
 // @Success 200 {object} []map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -90,16 +92,14 @@ func (h *Handler) GetPaidStorageDownload(w http.ResponseWriter, r *http.Request)
 	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
 	defer cancel()
 
-	taskId := r.URL.Query().Get("taskId")
-	if taskId == "" {
-		h.logger.Error().Msg("missing required param: taskId")
-		http.Error(w, "missing required param: taskId", http.StatusBadRequest)
+	tokenIdx, taskId, ok := reportTaskParams(w, r)
+	if !ok {
 		return
 	}
 
-	data, err := h.api.GetPaidStorageDownload(ctx, taskId)
+	data, err := h.api.GetPaidStorageDownload(ctx, tokenIdx, taskId)
 	if err != nil {
-		h.logger.Error().Err(err).Msg("StartPaidStorage failed")
+		h.logger.Error().Err(err).Msg("GetPaidStorageDownload failed")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+	"wildberriesapi/internal/pricing"
+)
+
+// uploadPricesRequest — тело запроса на загрузку цен
+type uploadPricesRequest struct {
+	TokenIdx int               `json:"token_idx"`
+	Items    []api.PriceUpdate `json:"items"`
+}
+
+// UploadPrices godoc
+// @Summary Загрузить цены и скидки в WB
+// @Description Создаёт задачи загрузки цен (пачками по 1000) и ждёт их обработки; ошибки возвращаются по nmID.
+// @Description С dryRun=true ничего не отправляет и возвращает diff с текущими ценами. Требует X-API-Key.
+// @Tags Prices
+// @Accept json
+// @Param dryRun query bool false "Только показать diff"
+// @Param body body map[string]interface{} true "{token_idx, items: [{nmID, price, discount}]}"
+// @Success 200 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /api/prices/upload [post]
+func (h *Handler) UploadPrices(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	var req uploadPricesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := pricing.Validate(req.Items); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.pricing.Apply(ctx, req.TokenIdx, req.Items, dryRun, 90*time.Second)
+
+	entry := audit.Entry{
+		Action:   "prices.upload",
+		Target:   fmt.Sprintf("%d items", len(req.Items)),
+		TokenIdx: req.TokenIdx,
+		DryRun:   dryRun,
+		Payload:  res,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.OutcomeUnknown = errors.Is(err, api.ErrOutcomeUnknown)
+	}
+	h.recordAudit(r, entry)
+
+	if err != nil {
+		h.logger.Error().Err(err).Msg("UploadPrices failed")
+		// 504 — задача могла быть создана: перед повтором проверить историю загрузок
+		http.Error(w, err.Error(), writeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if res.Pending {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetPriceUploadStatus godoc
+// @Summary Статус загрузки цен
+// @Description Возвращает статус задачи загрузки цен и ошибки по nmID (для обработанных задач). Требует X-API-Key.
+// @Tags Prices
+// @Param uploadId query int true "ID задачи загрузки"
+// @Param token_idx query int false "Номер токена (с 1)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/prices/upload/status [get]
+func (h *Handler) GetPriceUploadStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	uploadID, err := strconv.ParseInt(r.URL.Query().Get("uploadId"), 10, 64)
+	if err != nil {
+		http.Error(w, "missing or invalid required param: uploadId", http.StatusBadRequest)
+		return
+	}
+	tokenIdx, _ := strconv.Atoi(r.URL.Query().Get("token_idx"))
+
+	status, err := h.api.GetPriceUploadStatus(ctx, tokenIdx, uploadID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetPriceUploadStatus failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res := api.PriceUploadResult{
+		Task:   api.PriceUploadTask{UploadID: uploadID, TokenIdx: tokenIdx},
+		Status: *status,
+		Errors: make([]api.PriceGoodResult, 0),
+	}
+	if status.Finished() && status.Status != api.PriceTaskDone {
+		goods, err := h.api.GetPriceUploadGoods(ctx, tokenIdx, uploadID)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("GetPriceUploadGoods failed")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, g := range goods {
+			if g.ErrorText != "" {
+				res.Errors = append(res.Errors, g)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -10,25 +10,81 @@ import (
 	"wildberriesapi/internal/api"
 )
 
-// NewRouter создает HTTP маршруты
-func NewRouter(api *api.WBClient, log zerolog.Logger) http.Handler {
+// NewRouter создает HTTP маршруты.
+// apiKeys — ключи доступа к операциям записи в WB (ключ → пользователь).
+func NewRouter(api *api.WBClient, log zerolog.Logger, apiKeys map[string]string, opts ...Option) http.Handler {
 	r := chi.NewRouter()
 
-	handler := NewHandler(api, log)
+	handler := NewHandler(api, log, opts...)
 	//orders := NewOrdersHandler(api, log)
 	//sales := NewSalesHandler(api, log)
 	//stocks := NewStocksHandler(api, log)
 
 	r.Get("/api/orders", handler.GetOrders)
 	r.Get("/api/sales", handler.GetSales)
+	r.Get("/api/buyouts", handler.GetBuyoutRates)
+	r.Get("/api/assortment/abc-xyz", handler.GetABCXYZ)
 	r.Get("/api/stocks", handler.GetStocks)
+	r.Get("/api/stocks/forecast", handler.GetStockForecast)
 	r.Get("/api/incomes", handler.GetIncomes)
 	r.Get("/api/tariffs", handler.GetTariffs)
 	r.Get("/api/tariffs/box", handler.GetTariffsBox)
 	r.Get("/api/tariffs/pallet", handler.GetTariffsPallet)
-	r.Get("/api/paid_storage/start", handler.StartPaidStorage)
+	r.Get("/api/tariffs/calc", handler.GetTariffsCalc)
 	r.Get("/api/paid_storage/status", handler.GetPaidStorageStatus)
 	r.Get("/api/paid_storage/download", handler.GetPaidStorageDownload)
+	r.Get("/api/warehouse_remains/status", handler.GetWarehouseRemainsStatus)
+	r.Get("/api/warehouse_remains/download", handler.GetWarehouseRemainsDownload)
+	r.Get("/api/acceptance_report/status", handler.GetAcceptanceReportStatus)
+	r.Get("/api/acceptance_report/download", handler.GetAcceptanceReportDownload)
+	r.Get("/api/measurement_penalties", handler.GetMeasurementPenalties)
+	r.Get("/api/funnel/grouped", handler.GetGroupedFunnel)
+	r.Get("/api/supplies/warehouses", handler.GetSupplyWarehouses)
+	r.Get("/api/supplies/acceptance", handler.GetAcceptanceCoefficients)
+	r.Get("/api/supplies/cheapest", handler.GetCheapestWarehouses)
+	r.Get("/api/supplies/plan", handler.GetSupplyPlan)
+	r.Get("/api/feedbacks/unanswered", handler.GetUnansweredFeedbacks)
+	r.Get("/api/questions/unanswered", handler.GetUnansweredQuestions)
+	r.Get("/api/catalog", handler.GetCatalog)
+	r.Get("/api/fbs/orders/new", handler.GetNewFBSOrders)
+	r.Get("/api/fbs/orders/status", handler.GetFBSOrderStatuses)
+	r.Get("/api/fbs/supplies", handler.GetFBSSupplies)
+	r.Get("/api/fbs/stickers", handler.GetFBSStickers)
+	r.Get("/api/fbs/warehouses", handler.GetFBSWarehouses)
+	r.Get("/api/fbs/stocks", handler.GetFBSStocks)
+	r.Get("/api/fbs/stocks/sync/last", handler.GetLastFBSStockSync)
+	r.Get("/api/adverts/keywords/wasted", handler.GetWastedKeywords)
+	r.Get("/api/adverts/budget", handler.GetAdvertBudget)
+
+	// Операции записи в WB — только с API-ключом, всё пишется в журнал аудита
+	r.Group(func(r chi.Router) {
+		r.Use(RequireAPIKey(apiKeys))
+
+		r.Post("/api/feedbacks/answer", handler.AnswerFeedback)
+		r.Patch("/api/feedbacks/answer", handler.EditFeedbackAnswer)
+		r.Post("/api/feedbacks/viewed", handler.MarkFeedbackViewed)
+		r.Post("/api/questions/answer", handler.AnswerQuestion)
+		r.Patch("/api/questions/answer", handler.EditQuestionAnswer)
+		r.Post("/api/questions/viewed", handler.MarkQuestionViewed)
+		r.Post("/api/prices/upload", handler.UploadPrices)
+		r.Get("/api/prices/upload/status", handler.GetPriceUploadStatus)
+		r.Post("/api/fbs/supplies", handler.CreateFBSSupply)
+		r.Post("/api/fbs/supplies/orders", handler.AddOrdersToFBSSupply)
+		r.Post("/api/fbs/supplies/deliver", handler.DeliverFBSSupply)
+		r.Post("/api/fbs/stocks/sync", handler.SyncFBSStocks)
+		r.Post("/api/adverts/status", handler.ChangeAdvertStatus)
+		r.Post("/api/adverts/budget/deposit", handler.DepositAdvertBudget)
+		r.Post("/api/adverts/bids", handler.SetAdvertBids)
+		r.Post("/api/adverts/minus-phrases", handler.SetAdvertMinusPhrases)
+		// создание задач отчётов расходует лимиты WB по каждому токену
+		r.Get("/api/paid_storage/start", handler.StartPaidStorage)
+		r.Get("/api/warehouse_remains/start", handler.StartWarehouseRemains)
+		r.Get("/api/acceptance_report/start", handler.StartAcceptanceReport)
+		// юнит-экономика создаёт задачи платного хранения и расходует лимит статистики рекламы
+		r.Get("/api/economics/units", handler.GetUnitEconomics)
+		r.Get("/api/answers/templates", handler.GetAnswerTemplates)
+		r.Get("/api/audit", handler.GetAudit)
+	})
 
 	// Swagger UI
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
+
+	"wildberriesapi/internal/analytics"
 )
 
 // GetSales godoc
@@ -36,3 +39,93 @@ func (h *Handler) GetSales(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(data)
 }
+
+// GetBuyoutRates godoc
+// @Summary Процент выкупа и возвратов
+// @Description Заказы за последние window дней сводятся с продажами (saleID на S) и возвратами (на R) по srid.
+// @Description Незавершённые заказы (без продажи и отмены) в процент выкупа не входят.
+// @Tags Sales
+// @Param by query string false "Разрез: nm (по умолчанию), region или warehouse"
+// @Param window query int false "Окно в днях (по умолчанию 30)"
+// @Success 200 {object} []analytics.BuyoutRate
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/buyouts [get]
+func (h *Handler) GetBuyoutRates(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	by := q.Get("by")
+	if by == "" {
+		by = analytics.BuyoutByNm
+	}
+	if err := analytics.ValidBuyoutBy(by); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	window := 30
+	if v := q.Get("window"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 90 {
+			http.Error(w, "invalid param: window (1..90)", http.StatusBadRequest)
+			return
+		}
+		window = n
+	}
+
+	now := time.Now()
+	dateFrom := now.AddDate(0, 0, -window).Format("2006-01-02")
+	orders, err := h.api.GetOrders(ctx, dateFrom, "")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetBuyoutRates failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sales, err := h.api.GetSales(ctx, dateFrom)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetBuyoutRates failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics.BuyoutRates(orders, sales, by, window, now))
+}
+
+// GetABCXYZ godoc
+// @Summary ABC/XYZ-анализ ассортимента
+// @Description ABC — по накопленной доле выручки (A — до 80%, B — до 95%, C — остальное),
+// @Description XYZ — по коэффициенту вариации недельных продаж (X — до 10%, Y — до 25%, Z — выше).
+// @Description Возвращает матрицу 3×3 (количество товаров и доля выручки в ячейке) и классы товаров.
+// @Tags Sales
+// @Param window query int false "Окно продаж в днях (по умолчанию 90, минимум 14)"
+// @Success 200 {object} analytics.ABCXYZMatrix
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/assortment/abc-xyz [get]
+func (h *Handler) GetABCXYZ(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	window := 90
+	if v := r.URL.Query().Get("window"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 14 || n > 365 {
+			http.Error(w, "invalid param: window (14..365)", http.StatusBadRequest)
+			return
+		}
+		window = n
+	}
+
+	now := time.Now()
+	sales, err := h.api.GetSales(ctx, now.AddDate(0, 0, -window).Format("2006-01-02"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetABCXYZ failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics.ABCXYZ(sales, window, now))
+}
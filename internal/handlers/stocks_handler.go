@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
+
+	"wildberriesapi/internal/analytics"
 )
 
 // GetStocks godoc
@@ -36,3 +39,73 @@ func (h *Handler) GetStocks(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(data)
 }
+
+// GetStockForecast godoc
+// @Summary Прогноз обнуления остатков по складам WB
+// @Description Запас в днях = остаток / средние заказы в день за окно; сортировка — сначала закончившиеся и самые срочные.
+// @Description Статусы: out — товара нет, а заказы были; low — запаса меньше порога; ok — хватает.
+// @Tags Stocks
+// @Param velocity_days query int false "Окно для скорости заказов в днях (по умолчанию 14)"
+// @Param threshold_days query number false "Порог запаса в днях (по умолчанию 14)"
+// @Param status query string false "Только позиции со статусом: ok, low или out"
+// @Success 200 {object} []analytics.StockCover
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/stocks/forecast [get]
+func (h *Handler) GetStockForecast(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	velocityDays := 14
+	if v := q.Get("velocity_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 90 {
+			http.Error(w, "invalid param: velocity_days (1..90)", http.StatusBadRequest)
+			return
+		}
+		velocityDays = n
+	}
+	threshold := 14.0
+	if v := q.Get("threshold_days"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid param: threshold_days", http.StatusBadRequest)
+			return
+		}
+		threshold = n
+	}
+	status := q.Get("status")
+	if status != "" && status != analytics.CoverOK && status != analytics.CoverLow && status != analytics.CoverOut {
+		http.Error(w, "invalid param: status (ok, low or out)", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	stocks, err := h.api.GetStocks(ctx, "2019-06-20")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetStockForecast failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	orders, err := h.api.GetOrders(ctx, now.AddDate(0, 0, -velocityDays).Format("2006-01-02"), "")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetStockForecast failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	covers := analytics.StockCoverage(stocks, orders, velocityDays, threshold, now)
+	if status != "" {
+		filtered := make([]analytics.StockCover, 0, len(covers))
+		for _, c := range covers {
+			if c.Status == status {
+				filtered = append(filtered, c)
+			}
+		}
+		covers = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(covers)
+}
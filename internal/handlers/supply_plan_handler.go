@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/analytics"
+	"wildberriesapi/internal/export"
+)
+
+// GetSupplyPlan godoc
+// @Summary План поставок FBW
+// @Description Сколько единиц каждого товара отгрузить на какой склад WB, чтобы запаса хватило на target_days дней.
+// @Description Спрос делится по федеральным округам покупателей и относится на самый дешёвый по логистике склад,
+// @Description который реально отгружает в округ. Итог по товару не превышает нехватку всей сети с учётом остатков
+// @Description на других складах WB и возвратов в пути. Объёмы товаров берутся из каталога.
+// @Tags Supplies
+// @Param target_days query number false "Целевой запас в днях (по умолчанию 30)"
+// @Param velocity_days query int false "Окно для скорости заказов в днях (по умолчанию 14)"
+// @Param format query string false "Формат: json (по умолчанию), csv или xlsx"
+// @Success 200 {object} []analytics.SupplyLine
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/supplies/plan [get]
+func (h *Handler) GetSupplyPlan(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+
+	q := r.URL.Query()
+	params := analytics.ReplenishmentParams{TargetDays: 30, WindowDays: 14, Now: time.Now()}
+	if v := q.Get("target_days"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 || n > 365 {
+			http.Error(w, "invalid param: target_days (1..365)", http.StatusBadRequest)
+			return
+		}
+		params.TargetDays = n
+	}
+	if v := q.Get("velocity_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 90 {
+			http.Error(w, "invalid param: velocity_days (1..90)", http.StatusBadRequest)
+			return
+		}
+		params.WindowDays = n
+	}
+	format := strings.ToLower(q.Get("format"))
+	if format != "" && format != "json" && format != export.FormatCSV && format != export.FormatXLSX {
+		http.Error(w, "invalid param: format (json, csv or xlsx)", http.StatusBadRequest)
+		return
+	}
+
+	stocks, err := h.api.GetStocks(ctx, "2019-06-20")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetSupplyPlan failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	orders, err := h.api.GetOrders(ctx, params.Now.AddDate(0, 0, -params.WindowDays).Format("2006-01-02"), "")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("GetSupplyPlan failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// без тарифов склад выбирается по числу отгрузок в округ
+	tariffs, err := h.api.GetTariffsBox(ctx, params.Now.Format("2006-01-02"))
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("⚠️ GetSupplyPlan: box tariffs not available")
+	}
+
+	volumes := map[int64]float64{}
+	if h.catalog != nil {
+		for _, p := range h.catalog.List() {
+			volumes[p.NmID] = p.VolumeLiters
+		}
+	}
+
+	plan := analytics.PlanReplenishment(stocks, orders, tariffs, volumes, params)
+
+	if format == "" || format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(plan)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="supply_plan_`+params.Now.Format("2006-01-02")+`.`+format+`"`)
+	if err := export.Write(w, format, supplyPlanTable(plan)); err != nil {
+		h.logger.Error().Err(err).Msg("GetSupplyPlan export failed")
+	}
+}
+
+// supplyPlanTable — план поставок в виде таблицы для выгрузки
+func supplyPlanTable(plan []analytics.SupplyLine) export.Table {
+	t := export.Table{
+		Sheet: "План поставок",
+		Header: []string{"nmId", "Артикул продавца", "Склад", "Округа", "Заказов в день", "Остаток",
+			"Остаток в сети", "Возвраты в пути", "Целевой запас", "К поставке", "Объём, л",
+			"Логистика за ед., ₽", "Логистика итого, ₽"},
+	}
+	for _, l := range plan {
+		var delivery any
+		if l.DeliveryCost != nil {
+			delivery = *l.DeliveryCost
+		}
+		t.Rows = append(t.Rows, []any{l.NmID, l.SupplierArticle, l.WarehouseName, strings.Join(l.Regions, ", "),
+			l.OrdersPerDay, l.Stock, l.NetworkStock, l.InWayFromClient, l.TargetQty, l.Quantity, l.VolumeLiters,
+			delivery, l.LogisticsTotal})
+	}
+	return t
+}
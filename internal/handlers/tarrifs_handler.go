@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"wildberriesapi/internal/analytics"
 )
 
 // GetTariffs godoc
@@ -89,3 +93,78 @@ func (h *Handler) GetTariffsPallet(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(data)
 }
+
+// GetTariffsCalc godoc
+// @Summary Расчёт логистики и хранения единицы товара на складе
+// @Description Считает по тарифам WB на дату: логистика = первый литр + каждый следующий литр, хранение — в день.
+// @Description Для паллет хранение считается за паллету. Поля без тарифа склада возвращаются как null.
+// @Tags Tariffs
+// @Param warehouse query string true "Название склада"
+// @Param liters query number true "Объём единицы товара, л"
+// @Param type query string false "Тип тарифа: box (по умолчанию) или pallet"
+// @Param date query string false "Дата тарифа (YYYY-MM-DD), по умолчанию — сегодня"
+// @Success 200 {object} analytics.LogisticsQuote
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tariffs/calc [get]
+func (h *Handler) GetTariffsCalc(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	q := r.URL.Query()
+	warehouse := q.Get("warehouse")
+	if warehouse == "" {
+		http.Error(w, "missing required param: warehouse", http.StatusBadRequest)
+		return
+	}
+	liters, err := strconv.ParseFloat(strings.Replace(q.Get("liters"), ",", ".", 1), 64)
+	if err != nil || liters <= 0 {
+		http.Error(w, "invalid param: liters", http.StatusBadRequest)
+		return
+	}
+	kind := q.Get("type")
+	if kind == "" {
+		kind = analytics.LogisticsBox
+	}
+	date := q.Get("date")
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		http.Error(w, "invalid param: date", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		quote analytics.LogisticsQuote
+		found bool
+	)
+	switch kind {
+	case analytics.LogisticsBox:
+		tariffs, err := h.api.GetTariffsBox(ctx, date)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("GetTariffsCalc failed")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		quote, found = analytics.CalcBoxLogistics(tariffs, warehouse, liters)
+	case analytics.LogisticsPallet:
+		tariffs, err := h.api.GetTariffsPallet(ctx, date)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("GetTariffsCalc failed")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		quote, found = analytics.CalcPalletLogistics(tariffs, warehouse, liters)
+	default:
+		http.Error(w, "invalid param: type (box or pallet)", http.StatusBadRequest)
+		return
+	}
+	if !found {
+		http.Error(w, "warehouse not found in tariffs: "+warehouse, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
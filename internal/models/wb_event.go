@@ -2,9 +2,11 @@
 package models
 
 type WBEvent struct {
-	Type       string      `json:"type"` // "sales", "orders", "stocks"
+	Type       string      `json:"type"`             // "sales", "orders", "stocks"
+	Action     string      `json:"action,omitempty"` // "new", "updated"
 	SupplierID int         `json:"supplier_id"`
 	Data       interface{} `json:"data"`
 	CreatedAt  string      `json:"created_at"`
-	Source     string      `json:"source"` // "wildberries"
+	Source     string      `json:"source"`               // "wildberries"
+	Incomplete bool        `json:"incomplete,omitempty"` // выгрузка прервана, данные частичные
 }
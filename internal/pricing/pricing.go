@@ -0,0 +1,190 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"wildberriesapi/internal/api"
+)
+
+// Diff — изменение цены/скидки одного товара относительно текущих значений в WB
+type Diff struct {
+	NmID           int64   `json:"nmID"`
+	VendorCode     string  `json:"vendorCode,omitempty"`
+	OldPrice       float64 `json:"oldPrice"`
+	NewPrice       float64 `json:"newPrice"`
+	OldDiscount    float64 `json:"oldDiscount"`
+	NewDiscount    float64 `json:"newDiscount"`
+	OldFinalPrice  float64 `json:"oldFinalPrice"`
+	NewFinalPrice  float64 `json:"newFinalPrice"`
+	PriceChangePct float64 `json:"priceChangePct"`
+	Unchanged      bool    `json:"unchanged,omitempty"`
+	Error          string  `json:"error,omitempty"`
+}
+
+// Result — итог загрузки (или пробного прогона)
+type Result struct {
+	DryRun  bool                    `json:"dryRun"`
+	Diff    []Diff                  `json:"diff"`
+	Tasks   []api.PriceUploadTask   `json:"tasks,omitempty"`
+	Uploads []api.PriceUploadResult `json:"uploads,omitempty"`
+	// Errors — ошибки WB по nmId
+	Errors map[int64]string `json:"errors,omitempty"`
+	// Pending — задачи ещё обрабатываются, статус можно запросить позже
+	Pending bool `json:"pending,omitempty"`
+}
+
+// Service — загрузка цен и скидок с предварительным расчётом diff
+type Service struct {
+	api *api.WBClient
+}
+
+func NewService(client *api.WBClient) *Service {
+	return &Service{api: client}
+}
+
+// Plan сравнивает запрошенные цены с текущими и возвращает diff.
+// Товары, которых нет в WB для этого токена, помечаются ошибкой; если текущие цены
+// загрузить не удалось, план не строится.
+func (s *Service) Plan(ctx context.Context, tokenIdx int, updates []api.PriceUpdate) ([]Diff, error) {
+	current, err := s.api.GetTokenPrices(ctx, tokenIdx, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("load current prices: %w", err)
+	}
+
+	byNm := make(map[int64]api.PriceItem, len(current))
+	for _, p := range current {
+		byNm[p.ID] = p
+	}
+
+	diff := make([]Diff, 0, len(updates))
+	for _, u := range updates {
+		d := Diff{NmID: u.NmID}
+		cur, ok := byNm[u.NmID]
+		if !ok {
+			d.Error = "nmID not found in current prices"
+			diff = append(diff, d)
+			continue
+		}
+
+		d.VendorCode = cur.SupplierArt
+		d.OldPrice, d.NewPrice = cur.Price, cur.Price
+		d.OldDiscount, d.NewDiscount = cur.Discount, cur.Discount
+		if u.Price != nil {
+			d.NewPrice = float64(*u.Price)
+		}
+		if u.Discount != nil {
+			d.NewDiscount = float64(*u.Discount)
+		}
+		d.OldFinalPrice = finalPrice(d.OldPrice, d.OldDiscount)
+		d.NewFinalPrice = finalPrice(d.NewPrice, d.NewDiscount)
+		if d.OldFinalPrice != 0 {
+			d.PriceChangePct = round2((d.NewFinalPrice - d.OldFinalPrice) / d.OldFinalPrice * 100)
+		}
+		d.Unchanged = d.OldPrice == d.NewPrice && d.OldDiscount == d.NewDiscount
+		diff = append(diff, d)
+	}
+
+	sort.Slice(diff, func(i, j int) bool { return diff[i].NmID < diff[j].NmID })
+	return diff, nil
+}
+
+// Apply загружает цены. При dryRun возвращает только diff.
+// wait — сколько ждать завершения задач; если не успели, Result.Pending = true.
+func (s *Service) Apply(ctx context.Context, tokenIdx int, updates []api.PriceUpdate, dryRun bool, wait time.Duration) (*Result, error) {
+	if err := Validate(updates); err != nil {
+		return nil, err
+	}
+
+	diff, err := s.Plan(ctx, tokenIdx, updates)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{DryRun: dryRun, Diff: diff, Errors: map[int64]string{}}
+	if dryRun {
+		return res, nil
+	}
+
+	// в WB уходят только товары, которые существуют и действительно меняются
+	send := make([]api.PriceUpdate, 0, len(updates))
+	changed := make(map[int64]bool, len(diff))
+	for _, d := range diff {
+		if d.Error != "" {
+			res.Errors[d.NmID] = d.Error
+			continue
+		}
+		if !d.Unchanged {
+			changed[d.NmID] = true
+		}
+	}
+	for _, u := range updates {
+		if changed[u.NmID] {
+			send = append(send, u)
+		}
+	}
+	if len(send) == 0 {
+		return res, nil
+	}
+
+	tasks, err := s.api.UploadPrices(ctx, tokenIdx, send)
+	res.Tasks = tasks
+	if err != nil {
+		return res, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	for _, t := range tasks {
+		up, err := s.api.WaitPriceUpload(waitCtx, t, 3*time.Second)
+		if err != nil {
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				res.Pending = true
+				continue
+			}
+			return res, err
+		}
+		res.Uploads = append(res.Uploads, *up)
+		for _, g := range up.Errors {
+			res.Errors[g.NmID] = g.ErrorText
+		}
+	}
+	return res, nil
+}
+
+// Validate проверяет запрос до обращения к WB
+func Validate(updates []api.PriceUpdate) error {
+	if len(updates) == 0 {
+		return fmt.Errorf("no items to upload")
+	}
+	seen := make(map[int64]bool, len(updates))
+	for _, u := range updates {
+		if u.NmID <= 0 {
+			return fmt.Errorf("invalid nmID %d", u.NmID)
+		}
+		if seen[u.NmID] {
+			return fmt.Errorf("duplicate nmID %d", u.NmID)
+		}
+		seen[u.NmID] = true
+		if u.Price == nil && u.Discount == nil {
+			return fmt.Errorf("nmID %d: price or discount is required", u.NmID)
+		}
+		if u.Price != nil && *u.Price <= 0 {
+			return fmt.Errorf("nmID %d: price must be positive", u.NmID)
+		}
+		if u.Discount != nil && (*u.Discount < 0 || *u.Discount > 99) {
+			return fmt.Errorf("nmID %d: discount must be in 0..99", u.NmID)
+		}
+	}
+	return nil
+}
+
+func finalPrice(price, discount float64) float64 {
+	return round2(price * (100 - discount) / 100)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -92,15 +92,29 @@ func NewKafkaPublisher(cfg config.Config) (Publisher, error) {
 		"wb.raw.sales",
 		"wb.raw.orders",
 		"wb.raw.stocks",
+		"wb.raw.stock_forecast",
 		"wb.raw.prices",
 		"wb.raw.tariffs",
 		"wb.raw.adverts",
+		"wb.raw.adverts.keywords",
+		"wb.raw.adverts.rules",
+		"wb.raw.paid_storage",
+		"wb.raw.warehouse_remains",
+		"wb.raw.acceptance_report",
+		"wb.raw.measurement_penalties",
+		"wb.raw.acceptance",
 		"wb.raw.searchtexts",
 		"wb.raw.analytics",
+		"wb.raw.reports",
+		"wb.raw.unit_economics",
+		"wb.raw.buyouts",
+		"wb.raw.assortment",
 		"wb.raw.finances",
 		"wb.raw.reviews",
 		"wb.raw.questions",
 		"wb.raw.answers",
+		"wb.raw.cards",
+		"wb.raw.fbs.orders",
 		"wb.raw.cancellations",
 		"wb.raw.finance.supplies",
 		"wb.raw.finance.returns",
@@ -0,0 +1,511 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"wildberriesapi/internal/advertising"
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/audit"
+	"wildberriesapi/internal/models"
+	"wildberriesapi/internal/publisher"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	decisionsTopic   = "wb.raw.adverts.rules"
+	cooldownStateKey = "advert_rules_cooldown"
+	auditUser        = "rules-engine"
+)
+
+// Decision — запись журнала решений: какое правило сработало на какой кампании и что было сделано
+type Decision struct {
+	Time     time.Time `json:"time"`
+	RuleID   string    `json:"rule_id"`
+	AdvertID int64     `json:"advert_id"`
+	TokenIdx int       `json:"token_idx"`
+	Metric   string    `json:"metric"`
+	Value    float64   `json:"value"`
+	Op       string    `json:"op"`
+	Target   float64   `json:"threshold"`
+	Action   string    `json:"action"`
+	DryRun   bool      `json:"dry_run"`
+	Applied  bool      `json:"applied"`
+	Skipped  string    `json:"skipped,omitempty"` // причина, по которой действие не выполнялось
+	Result   any       `json:"result,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Engine — каждый цикл проверяет правила по метрикам кампаний и выполняет действия через WBClient
+type Engine struct {
+	rules     []Rule
+	api       *api.WBClient
+	ads       *advertising.Service
+	publisher publisher.Publisher
+	state     state.Store
+	audit     audit.Logger
+	interval  time.Duration
+	dryRun    bool
+	logger    zerolog.Logger
+}
+
+// NewEngine создаёт движок правил. dryRun=true — ни одно правило не меняет кампании, только пишет решения.
+func NewEngine(rules []Rule, client *api.WBClient, ads *advertising.Service, pub publisher.Publisher, store state.Store, auditLog audit.Logger, interval time.Duration, dryRun bool, log zerolog.Logger) *Engine {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Engine{
+		rules:     rules,
+		api:       client,
+		ads:       ads,
+		publisher: pub,
+		state:     store,
+		audit:     auditLog,
+		interval:  interval,
+		dryRun:    dryRun,
+		logger:    log,
+	}
+}
+
+func (e *Engine) Run(ctx context.Context) {
+	e.logger.Info().Msgf("🚀 Starting advert rules engine (%d rules, interval: %s, dryRun: %t)", len(e.rules), e.interval, e.dryRun)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	e.Evaluate(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info().Msg("🛑 Advert rules engine stopped")
+			return
+		case <-ticker.C:
+			e.Evaluate(ctx)
+		}
+	}
+}
+
+// campaignData — данные кампании для расчёта метрик за цикл
+type campaignData struct {
+	ref    api.AdvertRef
+	stats  *api.AdvertFullStats
+	nmIDs  []int64
+	budget *float64
+}
+
+// positionStats — суммы по поисковым запросам товара для средней позиции
+type positionStats struct {
+	weighted, weight float64 // позиция × частота, частота
+	sum              float64
+	count            int
+}
+
+// positionCache — окно правила в днях → nmID → позиции товара за окно (на один токен и цикл)
+type positionCache map[int]map[int64]*positionStats
+
+// Evaluate выполняет один цикл проверки правил и возвращает принятые решения
+func (e *Engine) Evaluate(ctx context.Context) []Decision {
+	cooldowns := map[string]time.Time{}
+	if e.state != nil {
+		if _, err := e.state.Load(cooldownStateKey, &cooldowns); err != nil {
+			e.logger.Error().Err(err).Msg("❌ failed to load advert rules cooldowns")
+		}
+		if cooldowns == nil {
+			cooldowns = map[string]time.Time{}
+		}
+	}
+
+	byToken := map[int][]Rule{}
+	for _, r := range e.rules {
+		if r.Disabled {
+			continue
+		}
+		idx := r.TokenIdx
+		if idx == 0 {
+			idx = 1
+		}
+		byToken[idx] = append(byToken[idx], r)
+	}
+
+	decisions := make([]Decision, 0)
+	for tokenIdx, rules := range byToken {
+		campaigns, err := e.loadCampaigns(ctx, tokenIdx, rules)
+		if err != nil {
+			e.logger.Error().Err(err).Msgf("❌ advert rules: failed to load campaigns (token_%d)", tokenIdx)
+			continue
+		}
+
+		positions := e.loadPositions(ctx, tokenIdx, rules, campaigns)
+
+		for _, r := range rules {
+			for _, camp := range campaigns {
+				if !appliesTo(r, camp.ref) {
+					continue
+				}
+
+				value, ok, err := e.metric(ctx, tokenIdx, r, camp, positions)
+				if err != nil {
+					e.logger.Warn().Err(err).Msgf("⚠️ rule %s: metric %s not available for advert %d", r.ID, r.Metric, camp.ref.AdvertID)
+					continue
+				}
+				if !ok || !r.Match(value) {
+					continue
+				}
+
+				d := Decision{
+					Time:     time.Now(),
+					RuleID:   r.ID,
+					AdvertID: camp.ref.AdvertID,
+					TokenIdx: tokenIdx,
+					Metric:   r.Metric,
+					Value:    round2(value),
+					Op:       r.Op,
+					Target:   r.Value,
+					Action:   r.Action,
+					DryRun:   e.dryRun || r.DryRun,
+				}
+
+				key := r.ID + "/" + strconv.FormatInt(camp.ref.AdvertID, 10)
+				if last, ok := cooldowns[key]; ok && time.Since(last) < r.cooldown {
+					d.Skipped = "cooldown until " + last.Add(r.cooldown).Format(time.RFC3339)
+				} else {
+					d.Result, err = e.apply(ctx, tokenIdx, r, camp, d.DryRun)
+					if err != nil {
+						d.Error = err.Error()
+					} else if !d.DryRun {
+						d.Applied = true
+					}
+					// cooldown отсчитывается от любой реальной попытки: при ошибке (например, таймауте пополнения)
+					// действие могло выполниться в WB, и повтор в следующем цикле снова списал бы деньги
+					if !d.DryRun {
+						cooldowns[key] = d.Time
+					}
+					e.recordAudit(d)
+				}
+
+				decisions = append(decisions, d)
+				e.publish(ctx, d)
+			}
+		}
+	}
+
+	if e.state != nil {
+		if err := e.state.Save(cooldownStateKey, cooldowns); err != nil {
+			e.logger.Error().Err(err).Msg("❌ failed to save advert rules cooldowns")
+		}
+	}
+	e.logger.Info().Msgf("✅ Advert rules evaluated: %d decisions", len(decisions))
+	return decisions
+}
+
+// lastFullDay — начало последних завершённых суток (вчера): сегодняшние данные неполные
+func lastFullDay(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+}
+
+// loadCampaigns загружает кампании токена и их статистику за максимальное окно правил
+func (e *Engine) loadCampaigns(ctx context.Context, tokenIdx int, rules []Rule) ([]*campaignData, error) {
+	refs, err := e.api.GetAdvertIDs(ctx, tokenIdx, api.AdvertStatusActive, api.AdvertStatusPaused, api.AdvertStatusReady)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWindow := 1
+	for _, r := range rules {
+		if r.WindowDays > maxWindow {
+			maxWindow = r.WindowDays
+		}
+	}
+
+	campaigns := make([]*campaignData, 0, len(refs))
+	ids := make([]int64, 0, len(refs))
+	byID := map[int64]*campaignData{}
+	for _, ref := range refs {
+		cd := &campaignData{ref: ref}
+		campaigns = append(campaigns, cd)
+		byID[ref.AdvertID] = cd
+		// у готовых к запуску кампаний статистики ещё нет
+		if ref.Status != api.AdvertStatusReady {
+			ids = append(ids, ref.AdvertID)
+		}
+	}
+	if len(ids) == 0 {
+		return campaigns, nil
+	}
+
+	end := lastFullDay(time.Now())
+	full, err := e.api.GetAdvertFullStats(ctx, tokenIdx, ids, end.AddDate(0, 0, -(maxWindow-1)), end)
+	if err != nil {
+		return nil, err
+	}
+	for i := range full {
+		cd, ok := byID[full[i].AdvertID]
+		if !ok {
+			continue
+		}
+		cd.stats = &full[i]
+		seen := map[int64]bool{}
+		for _, row := range full[i].ByNmDay() {
+			if !seen[row.NmID] {
+				seen[row.NmID] = true
+				cd.nmIDs = append(cd.nmIDs, row.NmID)
+			}
+		}
+	}
+	return campaigns, nil
+}
+
+// loadPositions загружает отчёт поисковых запросов по товарам кампаний для правил с метрикой position.
+// Ошибка загрузки не прерывает цикл — такие правила в этом цикле не срабатывают.
+func (e *Engine) loadPositions(ctx context.Context, tokenIdx int, rules []Rule, campaigns []*campaignData) positionCache {
+	cache := positionCache{}
+	for _, r := range rules {
+		if r.Metric != MetricPosition || cache[r.WindowDays] != nil {
+			continue
+		}
+
+		seen := map[int64]bool{}
+		nmIDs := make([]int64, 0)
+		add := func(ids []int64) {
+			for _, id := range ids {
+				if !seen[id] {
+					seen[id] = true
+					nmIDs = append(nmIDs, id)
+				}
+			}
+		}
+		for _, rr := range rules {
+			if rr.Metric == MetricPosition && rr.WindowDays == r.WindowDays {
+				add(rr.NmIDs)
+			}
+		}
+		for _, camp := range campaigns {
+			add(camp.nmIDs)
+		}
+		if len(nmIDs) == 0 {
+			continue
+		}
+
+		end := lastFullDay(time.Now())
+		texts, err := e.api.GetSearchTexts(ctx, tokenIdx, api.SearchTextsQuery{
+			NmIDs: nmIDs,
+			From:  end.AddDate(0, 0, -(r.WindowDays - 1)).Format("2006-01-02"),
+			To:    end.Format("2006-01-02"),
+		})
+		if err != nil {
+			e.logger.Error().Err(err).Msgf("❌ advert rules: failed to load search positions (token_%d, %d days)", tokenIdx, r.WindowDays)
+			continue
+		}
+
+		byNm := map[int64]*positionStats{}
+		for _, t := range texts {
+			if t.AvgPosition.Current <= 0 {
+				continue
+			}
+			ps := byNm[t.NmID]
+			if ps == nil {
+				ps = &positionStats{}
+				byNm[t.NmID] = ps
+			}
+			ps.weighted += t.AvgPosition.Current * t.Frequency.Current
+			ps.weight += t.Frequency.Current
+			ps.sum += t.AvgPosition.Current
+			ps.count++
+		}
+		cache[r.WindowDays] = byNm
+	}
+	return cache
+}
+
+// appliesTo — подходит ли кампания под фильтр правила и допускает ли её статус действие
+func appliesTo(r Rule, ref api.AdvertRef) bool {
+	if len(r.AdvertIDs) > 0 {
+		found := false
+		for _, id := range r.AdvertIDs {
+			if id == ref.AdvertID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	switch r.Action {
+	case ActionPause:
+		return ref.Status == api.AdvertStatusActive
+	case ActionStart:
+		return ref.Status == api.AdvertStatusPaused || ref.Status == api.AdvertStatusReady
+	case ActionSetCPM:
+		return ref.Status == api.AdvertStatusActive || ref.Status == api.AdvertStatusPaused
+	}
+	return true
+}
+
+// metric считает значение метрики правила за его окно. ok=false — метрику посчитать нельзя.
+func (e *Engine) metric(ctx context.Context, tokenIdx int, r Rule, camp *campaignData, positions positionCache) (float64, bool, error) {
+	switch r.Metric {
+	case MetricBudget:
+		if camp.budget == nil {
+			b, err := e.api.GetAdvertBudget(ctx, tokenIdx, camp.ref.AdvertID)
+			if err != nil {
+				return 0, false, err
+			}
+			camp.budget = &b.Total
+		}
+		return *camp.budget, true, nil
+	case MetricPosition:
+		byNm, ok := positions[r.WindowDays]
+		if !ok {
+			return 0, false, fmt.Errorf("search positions not loaded")
+		}
+		nmIDs := r.NmIDs
+		if len(nmIDs) == 0 {
+			nmIDs = camp.nmIDs
+		}
+		var total positionStats
+		for _, nm := range nmIDs {
+			if ps := byNm[nm]; ps != nil {
+				total.weighted += ps.weighted
+				total.weight += ps.weight
+				total.sum += ps.sum
+				total.count += ps.count
+			}
+		}
+		switch {
+		case total.weight > 0:
+			return total.weighted / total.weight, true, nil
+		case total.count > 0:
+			return total.sum / float64(total.count), true, nil
+		}
+		return 0, false, nil
+	}
+
+	if camp.stats == nil {
+		return 0, false, nil
+	}
+
+	end := lastFullDay(time.Now())
+	from := end.AddDate(0, 0, -(r.WindowDays - 1)).Format("2006-01-02")
+	to := end.Format("2006-01-02")
+	var m api.AdvertMetrics
+	for _, d := range camp.stats.Days {
+		if day := d.Date.Format("2006-01-02"); day < from || day > to {
+			continue
+		}
+		m.Views += d.Views
+		m.Clicks += d.Clicks
+		m.Sum += d.Sum
+		m.Orders += d.Orders
+		m.SumPrice += d.SumPrice
+	}
+
+	switch r.Metric {
+	case MetricViews:
+		return float64(m.Views), true, nil
+	case MetricClicks:
+		return float64(m.Clicks), true, nil
+	case MetricSpend:
+		return m.Sum, true, nil
+	case MetricOrders:
+		return float64(m.Orders), true, nil
+	case MetricRevenue:
+		return m.SumPrice, true, nil
+	case MetricCTR:
+		if m.Views == 0 {
+			return 0, false, nil
+		}
+		return float64(m.Clicks) / float64(m.Views) * 100, true, nil
+	case MetricCPC:
+		if m.Clicks == 0 {
+			return 0, false, nil
+		}
+		return m.Sum / float64(m.Clicks), true, nil
+	case MetricCR:
+		if m.Clicks == 0 {
+			return 0, false, nil
+		}
+		return float64(m.Orders) / float64(m.Clicks) * 100, true, nil
+	case MetricDRR:
+		// расход без выручки считается ДРР 100%
+		if m.SumPrice == 0 {
+			if m.Sum == 0 {
+				return 0, false, nil
+			}
+			return 100, true, nil
+		}
+		return m.Sum / m.SumPrice * 100, true, nil
+	}
+	return 0, false, fmt.Errorf("unknown metric %q", r.Metric)
+}
+
+// apply выполняет действие правила через сервис управления рекламой
+func (e *Engine) apply(ctx context.Context, tokenIdx int, r Rule, camp *campaignData, dryRun bool) (any, error) {
+	id := camp.ref.AdvertID
+	switch r.Action {
+	case ActionPause:
+		return e.ads.ChangeStatus(ctx, tokenIdx, id, advertising.ActionPause, dryRun)
+	case ActionStart:
+		return e.ads.ChangeStatus(ctx, tokenIdx, id, advertising.ActionStart, dryRun)
+	case ActionDeposit:
+		return e.ads.Deposit(ctx, tokenIdx, id, r.Sum, r.Source, dryRun)
+	case ActionSetCPM:
+		nmIDs := r.NmIDs
+		if len(nmIDs) == 0 {
+			nmIDs = camp.nmIDs
+		}
+		if len(nmIDs) == 0 {
+			return nil, fmt.Errorf("no nm_ids for advert %d", id)
+		}
+		bid := api.AdvertBid{AdvertID: id}
+		for _, nm := range nmIDs {
+			bid.NmBids = append(bid.NmBids, api.AdvertNmBid{Nm: nm, Bid: r.CPM})
+		}
+		return e.ads.SetBids(ctx, tokenIdx, []api.AdvertBid{bid}, dryRun)
+	}
+	return nil, fmt.Errorf("unknown action %q", r.Action)
+}
+
+func (e *Engine) publish(ctx context.Context, d Decision) {
+	if e.publisher == nil {
+		return
+	}
+	event := models.WBEvent{
+		Type:      "advert_rule_decision",
+		Data:      d,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Source:    "wildberries",
+	}
+	if err := e.publisher.Publish(ctx, decisionsTopic, []byte(strconv.FormatInt(d.AdvertID, 10)), event); err != nil {
+		e.logger.Error().Err(err).Msgf("❌ failed to publish rule decision %s for advert %d", d.RuleID, d.AdvertID)
+	}
+}
+
+func (e *Engine) recordAudit(d Decision) {
+	if e.audit == nil {
+		return
+	}
+	entry := audit.Entry{
+		User:     auditUser,
+		Action:   "adverts.rule." + d.Action,
+		Target:   strconv.FormatInt(d.AdvertID, 10),
+		TokenIdx: d.TokenIdx,
+		DryRun:   d.DryRun,
+		Payload:  d,
+		Error:    d.Error,
+	}
+	if err := e.audit.Record(entry); err != nil {
+		e.logger.Error().Err(err).Msg("❌ failed to write audit entry")
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
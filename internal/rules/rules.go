@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Метрики, по которым могут срабатывать правила
+const (
+	MetricViews   = "views"
+	MetricClicks  = "clicks"
+	MetricCTR     = "ctr"
+	MetricCPC     = "cpc"
+	MetricSpend   = "spend"
+	MetricOrders  = "orders"
+	MetricRevenue = "revenue"
+	MetricCR      = "cr"
+	MetricDRR     = "drr"
+	MetricBudget  = "budget"
+	// MetricPosition — средняя позиция товаров кампании в поиске по отчёту поисковых запросов
+	// (взвешенно по частоте запросов); «позиция выше цели» (ближе к началу выдачи) — значение меньше порога
+	MetricPosition = "position"
+)
+
+// Действия правил
+const (
+	ActionPause   = "pause"
+	ActionStart   = "start"
+	ActionDeposit = "deposit"
+	ActionSetCPM  = "set_cpm"
+)
+
+var knownMetrics = map[string]bool{
+	MetricViews: true, MetricClicks: true, MetricCTR: true, MetricCPC: true, MetricSpend: true,
+	MetricOrders: true, MetricRevenue: true, MetricCR: true, MetricDRR: true, MetricBudget: true,
+	MetricPosition: true,
+}
+
+// Rule — декларативное правило: если metric за window_days завершённых дней (по вчера включительно)
+// удовлетворяет op value — выполнить action. Для deposit и set_cpm cooldown обязателен.
+//
+//	{"id": "pause-high-drr", "metric": "drr", "window_days": 3, "op": ">", "value": 25, "action": "pause", "cooldown": "24h"}
+type Rule struct {
+	ID        string  `json:"id"`
+	Disabled  bool    `json:"disabled"`
+	TokenIdx  int     `json:"token_idx"`
+	AdvertIDs []int64 `json:"advert_ids"` // пусто — все кампании токена
+
+	Metric     string  `json:"metric"`
+	WindowDays int     `json:"window_days"`
+	Op         string  `json:"op"` // >, >=, <, <=
+	Value      float64 `json:"value"`
+
+	Action string  `json:"action"`
+	Sum    int     `json:"sum"`    // deposit: сумма пополнения, ₽
+	Source int     `json:"source"` // deposit: источник (0 — счёт, 1 — баланс, 3 — бонусы)
+	CPM    int     `json:"cpm"`    // set_cpm: новая ставка
+	NmIDs  []int64 `json:"nm_ids"` // set_cpm: товары (пусто — все товары кампании из статистики)
+
+	Cooldown string `json:"cooldown"` // минимальный интервал между срабатываниями для одной кампании; обязателен для deposit и set_cpm
+	DryRun   bool   `json:"dry_run"`
+
+	cooldown time.Duration
+}
+
+// Match сравнивает значение метрики с порогом правила
+func (r Rule) Match(v float64) bool {
+	switch r.Op {
+	case ">":
+		return v > r.Value
+	case ">=":
+		return v >= r.Value
+	case "<":
+		return v < r.Value
+	case "<=":
+		return v <= r.Value
+	}
+	return false
+}
+
+// Validate проверяет правило и разбирает cooldown
+func (r *Rule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule without id")
+	}
+	if !knownMetrics[r.Metric] {
+		return fmt.Errorf("rule %s: unknown metric %q", r.ID, r.Metric)
+	}
+	if r.WindowDays <= 0 {
+		r.WindowDays = 1
+	}
+	if r.WindowDays > 30 {
+		return fmt.Errorf("rule %s: window_days must be at most 30", r.ID)
+	}
+	switch r.Op {
+	case ">", ">=", "<", "<=":
+	default:
+		return fmt.Errorf("rule %s: unknown op %q", r.ID, r.Op)
+	}
+	switch r.Action {
+	case ActionPause, ActionStart:
+	case ActionDeposit:
+		if r.Sum <= 0 {
+			return fmt.Errorf("rule %s: deposit requires positive sum", r.ID)
+		}
+	case ActionSetCPM:
+		if r.CPM <= 0 {
+			return fmt.Errorf("rule %s: set_cpm requires positive cpm", r.ID)
+		}
+	default:
+		return fmt.Errorf("rule %s: unknown action %q", r.ID, r.Action)
+	}
+	if r.Cooldown != "" {
+		d, err := time.ParseDuration(r.Cooldown)
+		if err != nil {
+			return fmt.Errorf("rule %s: invalid cooldown: %w", r.ID, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("rule %s: cooldown must not be negative", r.ID)
+		}
+		r.cooldown = d
+	}
+	// без cooldown правило с тратой денег срабатывало бы в каждом цикле, пока условие истинно
+	if (r.Action == ActionDeposit || r.Action == ActionSetCPM) && r.cooldown <= 0 {
+		return fmt.Errorf("rule %s: %s requires positive cooldown", r.ID, r.Action)
+	}
+	return nil
+}
+
+// LoadRules читает правила из JSON-файла (массив Rule). Пустой путь — правил нет.
+func LoadRules(path string) ([]Rule, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read advert rules: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("parse advert rules: %w", err)
+	}
+
+	seen := make(map[string]bool, len(rules))
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, err
+		}
+		if seen[rules[i].ID] {
+			return nil, fmt.Errorf("duplicate rule id %q", rules[i].ID)
+		}
+		seen[rules[i].ID] = true
+	}
+	return rules, nil
+}
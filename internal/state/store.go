@@ -0,0 +1,102 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Store — хранилище состояния коллекторов (курсоры, задачи, снапшоты).
+// Значения сериализуются в JSON, ключ — произвольная строка.
+type Store interface {
+	// Load читает значение по ключу в v. Возвращает false, если ключа нет.
+	Load(key string, v any) (bool, error)
+	Save(key string, v any) error
+	Delete(key string) error
+}
+
+// FileStore — реализация Store поверх директории: один ключ — один JSON-файл
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore создаёт хранилище в указанной директории (создаёт её при необходимости)
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("state dir is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create state dir %s: %w", dir, err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(key string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_", " ", "_").Replace(key)
+	return filepath.Join(s.dir, name+".json")
+}
+
+// Load читает значение по ключу
+func (s *FileStore) Load(key string, v any) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read state %s: %w", key, err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return false, fmt.Errorf("unmarshal state %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Save атомарно записывает значение (через временный файл + rename)
+func (s *FileStore) Save(key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal state %s: %w", key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := s.path(key)
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp state file: %w", err)
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write state %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close state %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("rename state %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete удаляет ключ (отсутствие ключа не считается ошибкой)
+func (s *FileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete state %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package stocksync
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job — периодическая синхронизация остатков из файла-фида
+type Job struct {
+	service  *Service
+	feedPath string
+	tokenIdx int
+	dryRun   bool
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+func NewJob(service *Service, feedPath string, tokenIdx int, dryRun bool, interval time.Duration, log zerolog.Logger) *Job {
+	return &Job{
+		service:  service,
+		feedPath: feedPath,
+		tokenIdx: tokenIdx,
+		dryRun:   dryRun,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+func (j *Job) Run(ctx context.Context) {
+	j.logger.Info().Msgf("🚀 Starting FBS stock sync loop (feed: %s, interval: %s, dryRun: %t)", j.feedPath, j.interval, j.dryRun)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.syncOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("🛑 FBS stock sync stopped")
+			return
+		case <-ticker.C:
+			j.syncOnce(ctx)
+		}
+	}
+}
+
+func (j *Job) syncOnce(ctx context.Context) {
+	items, err := LoadFeed(j.feedPath)
+	if err != nil {
+		j.logger.Error().Err(err).Msgf("❌ failed to read stock feed %s", j.feedPath)
+		return
+	}
+	rep, err := j.service.Reconcile(ctx, j.tokenIdx, items, j.dryRun)
+	if err != nil {
+		j.logger.Error().Err(err).Msg("❌ FBS stock sync failed")
+		return
+	}
+	for whID, msg := range rep.Errors {
+		j.logger.Warn().Msgf("⚠️ FBS stock sync: warehouse %d: %s", whID, msg)
+	}
+}
@@ -0,0 +1,272 @@
+package stocksync
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wildberriesapi/internal/api"
+	"wildberriesapi/internal/state"
+
+	"github.com/rs/zerolog"
+)
+
+// lastReportKey — ключ state для последнего отчёта синхронизации
+const lastReportKey = "fbs_stock_sync_last"
+
+// Item — остаток SKU на складе продавца по данным источника (учётной системы)
+type Item struct {
+	WarehouseID int64  `json:"warehouse_id"`
+	Sku         string `json:"sku"`
+	Amount      int    `json:"amount"`
+}
+
+// Diff — расхождение остатка между источником и WB
+type Diff struct {
+	WarehouseID int64  `json:"warehouse_id"`
+	Sku         string `json:"sku"`
+	WBAmount    int    `json:"wb_amount"`
+	FeedAmount  int    `json:"feed_amount"`
+	Delta       int    `json:"delta"`
+}
+
+// Report — итог синхронизации (или пробного прогона)
+type Report struct {
+	StartedAt time.Time `json:"started_at"`
+	TokenIdx  int       `json:"token_idx"`
+	DryRun    bool      `json:"dry_run"`
+	Checked   int       `json:"checked"`
+	Diffs     []Diff    `json:"diffs"`
+	Updated   int       `json:"updated"`
+	// Errors — ошибки по складам (ключ — ID склада)
+	Errors map[int64]string `json:"errors,omitempty"`
+}
+
+// Service — сверка остатков источника с остатками FBS в WB
+type Service struct {
+	api    *api.WBClient
+	state  state.Store
+	logger zerolog.Logger
+}
+
+func NewService(client *api.WBClient, store state.Store, log zerolog.Logger) *Service {
+	return &Service{api: client, state: store, logger: log}
+}
+
+// Reconcile сравнивает остатки источника с WB и, если не dryRun, отправляет в WB только расхождения
+func (s *Service) Reconcile(ctx context.Context, tokenIdx int, items []Item, dryRun bool) (*Report, error) {
+	if err := Validate(items); err != nil {
+		return nil, err
+	}
+	if tokenIdx == 0 {
+		tokenIdx = 1
+	}
+
+	warehouses, err := s.api.GetFBSWarehouses(ctx, tokenIdx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]bool, len(warehouses))
+	for _, w := range warehouses {
+		known[w.ID] = true
+	}
+
+	byWarehouse := map[int64][]Item{}
+	for _, it := range items {
+		byWarehouse[it.WarehouseID] = append(byWarehouse[it.WarehouseID], it)
+	}
+	ids := make([]int64, 0, len(byWarehouse))
+	for id := range byWarehouse {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rep := &Report{
+		StartedAt: time.Now(),
+		TokenIdx:  tokenIdx,
+		DryRun:    dryRun,
+		Diffs:     make([]Diff, 0),
+		Errors:    map[int64]string{},
+	}
+
+	for _, whID := range ids {
+		whItems := byWarehouse[whID]
+		if !known[whID] {
+			rep.Errors[whID] = "warehouse not found for this token"
+			continue
+		}
+
+		skus := make([]string, 0, len(whItems))
+		for _, it := range whItems {
+			skus = append(skus, it.Sku)
+		}
+		current, err := s.api.GetFBSStocks(ctx, tokenIdx, whID, skus)
+		if err != nil {
+			rep.Errors[whID] = err.Error()
+			continue
+		}
+		wb := make(map[string]int, len(current))
+		for _, st := range current {
+			wb[st.Sku] = st.Amount
+		}
+
+		update := make([]api.FBSStock, 0)
+		for _, it := range whItems {
+			rep.Checked++
+			// SKU, которого нет в ответе WB, считается с нулевым остатком
+			if wb[it.Sku] == it.Amount {
+				continue
+			}
+			rep.Diffs = append(rep.Diffs, Diff{
+				WarehouseID: whID,
+				Sku:         it.Sku,
+				WBAmount:    wb[it.Sku],
+				FeedAmount:  it.Amount,
+				Delta:       it.Amount - wb[it.Sku],
+			})
+			update = append(update, api.FBSStock{Sku: it.Sku, Amount: it.Amount})
+		}
+
+		if dryRun || len(update) == 0 {
+			continue
+		}
+		// пачки, отправленные до ошибки, уже применены в WB — учитываем их в Updated
+		applied, err := s.api.UpdateFBSStocks(ctx, tokenIdx, whID, update)
+		rep.Updated += applied
+		if err != nil {
+			rep.Errors[whID] = err.Error()
+		}
+	}
+
+	s.logger.Info().Msgf("📦 FBS stock sync: checked=%d, diffs=%d, updated=%d, errors=%d, dryRun=%t",
+		rep.Checked, len(rep.Diffs), rep.Updated, len(rep.Errors), dryRun)
+
+	if s.state != nil {
+		if err := s.state.Save(lastReportKey, rep); err != nil {
+			s.logger.Error().Err(err).Msg("❌ failed to save FBS stock sync report")
+		}
+	}
+	return rep, nil
+}
+
+// LastReport возвращает отчёт последней синхронизации (nil, если её ещё не было)
+func (s *Service) LastReport() (*Report, error) {
+	if s.state == nil {
+		return nil, nil
+	}
+	var rep Report
+	ok, err := s.state.Load(lastReportKey, &rep)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &rep, nil
+}
+
+// Validate проверяет фид до обращения к WB
+func Validate(items []Item) error {
+	if len(items) == 0 {
+		return errors.New("stock feed is empty")
+	}
+	seen := make(map[string]bool, len(items))
+	for _, it := range items {
+		if it.WarehouseID <= 0 {
+			return fmt.Errorf("sku %q: invalid warehouse_id %d", it.Sku, it.WarehouseID)
+		}
+		if it.Sku == "" {
+			return fmt.Errorf("warehouse %d: empty sku", it.WarehouseID)
+		}
+		if it.Amount < 0 {
+			return fmt.Errorf("sku %q: amount must not be negative", it.Sku)
+		}
+		key := strconv.FormatInt(it.WarehouseID, 10) + "/" + it.Sku
+		if seen[key] {
+			return fmt.Errorf("duplicate sku %q on warehouse %d", it.Sku, it.WarehouseID)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// LoadFeed читает фид из файла: .csv — CSV, иначе JSON
+func LoadFeed(path string) ([]Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ParseCSV(f)
+	}
+	return ParseJSON(f)
+}
+
+// ParseJSON разбирает фид вида [{"warehouse_id": 1, "sku": "...", "amount": 5}]
+func ParseJSON(r io.Reader) ([]Item, error) {
+	var items []Item
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("parse stock feed json: %w", err)
+	}
+	return items, nil
+}
+
+// ParseCSV разбирает фид с заголовком warehouse_id,sku,amount (разделитель «,» или «;»)
+func ParseCSV(r io.Reader) ([]Item, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(strings.NewReader(string(raw)))
+	firstLine, _, _ := strings.Cut(string(raw), "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		cr.Comma = ';'
+	}
+	cr.TrimLeadingSpace = true
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse stock feed csv: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := map[string]int{}
+	for i, h := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, name := range []string{"warehouse_id", "sku", "amount"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("stock feed csv: missing column %q", name)
+		}
+	}
+
+	items := make([]Item, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		line := n + 2
+		whID, err := strconv.ParseInt(strings.TrimSpace(row[col["warehouse_id"]]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("stock feed csv line %d: invalid warehouse_id", line)
+		}
+		amount, err := strconv.Atoi(strings.TrimSpace(row[col["amount"]]))
+		if err != nil {
+			return nil, fmt.Errorf("stock feed csv line %d: invalid amount", line)
+		}
+		items = append(items, Item{
+			WarehouseID: whID,
+			Sku:         strings.TrimSpace(row[col["sku"]]),
+			Amount:      amount,
+		})
+	}
+	return items, nil
+}